@@ -0,0 +1,47 @@
+package day1
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/seq"
+)
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2021,
+		Day:  1,
+		Parts: []runner.PartFunc{
+			runner.Solve(parseMeasurements, simpleMeasurementIncreaseCount),
+			runner.Solve(parseMeasurements, slidingWindowMeasurementIncreaseCount),
+		},
+	})
+}
+
+func parseMeasurements(lines []string) ([]int64, error) {
+	measurements := []int64{}
+	for i, l := range lines {
+		for n, field := range strings.Fields(l) {
+			measurement, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return nil, input.ExpectedErr(i, input.FieldColumn(l, n), "a depth measurement", err)
+			}
+			measurements = append(measurements, measurement)
+		}
+	}
+
+	return measurements, nil
+}
+
+func simpleMeasurementIncreaseCount(measurements []int64) int {
+	return seq.Count(seq.Windows(measurements, 2), func(pair []int64) bool {
+		return pair[1] > pair[0]
+	})
+}
+
+func slidingWindowMeasurementIncreaseCount(measurements []int64) int {
+	windowSums := seq.Map(seq.Windows(measurements, 3), seq.Sum[int64])
+	return simpleMeasurementIncreaseCount(windowSums)
+}
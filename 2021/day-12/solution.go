@@ -0,0 +1,178 @@
+package day12
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/Takadimi/aoc/graph"
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/memo"
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/trace"
+)
+
+var tracer = trace.New("2021/day-12")
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2021,
+		Day:  12,
+		Parts: []runner.PartFunc{
+			runner.Solve(parseCaveMap, partOne),
+			runner.Solve(parseCaveMap, partTwo),
+		},
+	})
+}
+
+type cave struct {
+	name    string
+	toCaves []*cave
+	isBig   bool
+	// id is the cave's bit in a visitedSet.
+	id int
+}
+
+// visitedSet has bit id set for every small cave visited so far.
+type visitedSet uint64
+
+const maxCaves = 64
+
+// pathState is everything that decides how many ways there are on from a
+// cave: which small caves can't be entered again and whether the one allowed
+// revisit has been used up.
+type pathState struct {
+	cave       *cave
+	visited    visitedSet
+	usedDouble bool
+}
+
+func partOne(caveMap map[string]*cave) int {
+	// no revisits allowed is the same as having already used the one up
+	return countPaths(caveMap["start"], true)
+}
+
+func partTwo(caveMap map[string]*cave) int {
+	return countPaths(caveMap["start"], false)
+}
+
+func parseCaveMap(lines []string) (map[string]*cave, error) {
+	caveMap := make(map[string]*cave)
+	passages := graph.NewUndirected[string]()
+
+	for i, l := range lines {
+		field := strings.TrimSpace(l)
+		if field == "" {
+			continue
+		}
+		parts := strings.Split(field, "-")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, input.Expected(i, 0, "a passage between two caves like `A-b`")
+		}
+		caveAName := parts[0]
+		caveBName := parts[1]
+
+		caveA, hasCaveA := caveMap[caveAName]
+		if !hasCaveA {
+			caveA = new(cave)
+			caveA.name = caveAName
+			caveA.toCaves = make([]*cave, 0)
+			if caveAName == strings.ToUpper(caveAName) {
+				caveA.isBig = true
+			}
+			caveA.id = len(caveMap)
+			caveMap[caveAName] = caveA
+		}
+		caveB, hasCaveB := caveMap[caveBName]
+		if !hasCaveB {
+			caveB = new(cave)
+			caveB.name = caveBName
+			caveB.toCaves = make([]*cave, 0)
+			if caveBName == strings.ToUpper(caveBName) {
+				caveB.isBig = true
+			}
+			caveB.id = len(caveMap)
+			caveMap[caveBName] = caveB
+		}
+		if len(caveMap) > maxCaves {
+			return nil, input.Expected(i, 0, fmt.Sprintf("no more than %d caves", maxCaves))
+		}
+		if caveA.isBig && caveB.isBig {
+			// there'd be no end to the paths going back and forth between them
+			return nil, input.Expected(i, 0, "a small cave at one end of the passage")
+		}
+
+		caveA.toCaves = append(caveA.toCaves, caveB)
+		caveB.toCaves = append(caveB.toCaves, caveA)
+		passages.AddEdge(caveAName, caveBName)
+	}
+
+	traceMap(caveMap)
+
+	for _, name := range []string{"start", "end"} {
+		if _, hasCave := caveMap[name]; !hasCave {
+			return nil, input.Expected(len(lines)-1, 0, "a passage to the "+name+" cave")
+		}
+	}
+	if !passages.PathExists("start", "end") {
+		return nil, input.Expected(len(lines)-1, 0, "a way through from the start cave to the end cave")
+	}
+
+	return caveMap, nil
+}
+
+// countPaths counts the paths from start to the end cave. Small caves can
+// only be visited once, except that one small cave may be visited twice
+// unless usedDouble is set. Paths that reach the same cave having visited the
+// same small caves go the same ways from there, so they're only counted once.
+func countPaths(start *cave, usedDouble bool) int {
+	paths := memo.New(func(recurse func(pathState) int, s pathState) int {
+		if s.cave.name == "end" {
+			return 1
+		}
+
+		count := 0
+		for _, next := range s.cave.toCaves {
+			if next.name == "start" {
+				continue
+			}
+
+			nextState := pathState{cave: next, visited: s.visited, usedDouble: s.usedDouble}
+			if !next.isBig {
+				bit := visitedSet(1) << next.id
+				if s.visited&bit != 0 {
+					if s.usedDouble {
+						continue
+					}
+					nextState.usedDouble = true
+				}
+				nextState.visited |= bit
+			}
+			count += recurse(nextState)
+		}
+		return count
+	})
+
+	return paths.Get(pathState{cave: start, visited: visitedSet(1) << start.id, usedDouble: usedDouble})
+}
+
+// traceMap lists the caves each cave leads to, under `--debug caves.map`.
+func traceMap(m map[string]*cave) {
+	if !tracer.Enabled(slog.LevelDebug, "caves.map") {
+		return
+	}
+
+	names := []string{}
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		toCaves := []string{}
+		for _, tc := range m[name].toCaves {
+			toCaves = append(toCaves, tc.name)
+		}
+		tracer.Debug("caves.map", "cave", "name", name, "big", m[name].isBig, "to", toCaves)
+	}
+}
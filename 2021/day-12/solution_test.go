@@ -0,0 +1,47 @@
+package day12
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseCaveMap(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		caveMap, err := parseCaveMap(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkCaveMap(caveMap); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkCaveMap makes sure the caves have ids that fit in a visitedSet and
+// only lead to one another, with no passage between two big caves.
+func checkCaveMap(caveMap map[string]*cave) error {
+	if caveMap["start"] == nil || caveMap["end"] == nil {
+		return errors.New("no start or end cave")
+	}
+	ids := map[int]bool{}
+	for name, c := range caveMap {
+		if c.name != name || c.id < 0 || c.id >= maxCaves || ids[c.id] {
+			return fmt.Errorf("cave %s has name %s and id %d", name, c.name, c.id)
+		}
+		ids[c.id] = true
+		for _, to := range c.toCaves {
+			if caveMap[to.name] != to {
+				return fmt.Errorf("cave %s leads to %s, which isn't on the map", name, to.name)
+			}
+			if c.isBig && to.isBig {
+				return fmt.Errorf("big caves %s and %s are joined", name, to.name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package day2
+
+import (
+	"context"
+
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/vm"
+)
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2021,
+		Day:  2,
+		Parts: []runner.PartFunc{
+			runner.SolveCtx(parseCommands, partOne),
+			runner.SolveCtx(parseCommands, partTwo),
+		},
+	})
+}
+
+// pilot is the submarine's instruction set as first understood, moving it
+// directly.
+var pilot = vm.NewISA(
+	vm.Opcode{Mnemonic: "forward", Arity: 1, Cycles: 1, Exec: func(m *vm.Machine, args []vm.Operand) int {
+		m.Registers["horizontal"] += m.Value(args[0])
+		return 1
+	}},
+	vm.Opcode{Mnemonic: "down", Arity: 1, Cycles: 1, Exec: func(m *vm.Machine, args []vm.Operand) int {
+		m.Registers["depth"] += m.Value(args[0]) // going down increases depth in ocean
+		return 1
+	}},
+	vm.Opcode{Mnemonic: "up", Arity: 1, Cycles: 1, Exec: func(m *vm.Machine, args []vm.Operand) int {
+		m.Registers["depth"] -= m.Value(args[0]) // going up decreases depth in ocean
+		return 1
+	}},
+)
+
+// pilotWithAim is the instruction set once the manual's been read, where up
+// and down only turn the submarine.
+var pilotWithAim = vm.NewISA(
+	vm.Opcode{Mnemonic: "forward", Arity: 1, Cycles: 1, Exec: func(m *vm.Machine, args []vm.Operand) int {
+		amount := m.Value(args[0])
+		m.Registers["horizontal"] += amount
+		m.Registers["depth"] += m.Registers["aim"] * amount
+		return 1
+	}},
+	vm.Opcode{Mnemonic: "down", Arity: 1, Cycles: 1, Exec: func(m *vm.Machine, args []vm.Operand) int {
+		m.Registers["aim"] += m.Value(args[0]) // aim goes up to increase depth in ocean
+		return 1
+	}},
+	vm.Opcode{Mnemonic: "up", Arity: 1, Cycles: 1, Exec: func(m *vm.Machine, args []vm.Operand) int {
+		m.Registers["aim"] -= m.Value(args[0]) // aim goes down to decrease depth in ocean
+		return 1
+	}},
+)
+
+func partOne(ctx context.Context, commands []vm.Instruction) (int, error) {
+	return pilotSubmarine(ctx, pilot, commands)
+}
+
+func partTwo(ctx context.Context, commands []vm.Instruction) (int, error) {
+	return pilotSubmarine(ctx, pilotWithAim, commands)
+}
+
+// pilotSubmarine runs the commands, returning the product of the final
+// horizontal position and depth.
+func pilotSubmarine(ctx context.Context, isa vm.ISA, commands []vm.Instruction) (int, error) {
+	m := vm.New(isa, commands, nil)
+	if err := m.Run(ctx); err != nil {
+		return 0, err
+	}
+	return m.Registers["horizontal"] * m.Registers["depth"], nil
+}
+
+// parseCommands reads the planned course. Both instruction sets share their
+// mnemonics, so either can check it.
+func parseCommands(lines []string) ([]vm.Instruction, error) {
+	return vm.Assemble(pilot, lines)
+}
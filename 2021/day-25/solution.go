@@ -0,0 +1,165 @@
+package day25
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Takadimi/aoc/checkpoint"
+	"github.com/Takadimi/aoc/cycle"
+	"github.com/Takadimi/aoc/grid"
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/trace"
+)
+
+var tracer = trace.New("2021/day-25")
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2021,
+		Day:  25,
+		Parts: []runner.PartFunc{
+			runner.SolveCtx(parseMap, partOne),
+		},
+	})
+}
+
+const (
+	EastboundCucumber  rune = '>'
+	SouthboundCucumber rune = 'v'
+	Empty              rune = '.'
+)
+
+// herdMoves is a rule group moving every cucumber of a herd one spot along
+// offset at once, if that spot was empty. Cells look back to see if a
+// cucumber arrives and ahead to see if theirs leaves.
+func herdMoves(herd rune, offset grid.Point) grid.RuleGroup[rune] {
+	behind := grid.Point{X: -offset.X, Y: -offset.Y}
+	return grid.RuleGroup[rune]{
+		Neighbourhood: grid.Neighbourhood{behind, offset},
+		Rule: func(cell rune, neighbours []rune) rune {
+			behind, ahead := neighbours[0], neighbours[1]
+			switch {
+			case cell == Empty && behind == herd:
+				return herd
+			case cell == herd && ahead == Empty:
+				return Empty
+			default:
+				return cell
+			}
+		},
+	}
+}
+
+func partOne(ctx context.Context, seafloorMap *grid.Grid[rune]) (int, error) {
+	seafloor := newSeafloor(seafloorMap)
+	steps, _, err := checkpoint.Resume(ctx, seafloor)
+	if err != nil {
+		return 0, err
+	}
+	seafloor.Steps = steps
+	traceMap(steps, seafloor.Grid())
+
+	// the herds stop once a step leaves the map as it was, which is a cycle of
+	// length one; any longer cycle means they'll never stop. Each state is
+	// the map as a string, a byte a cell, and maps are told apart by a hash.
+	// The automaton only does the stepping, starting from the state it's
+	// given each time.
+	var saveErr error
+	initial := seafloorState{steps: steps, cells: string(seafloor.Grid().Cells)}
+	history, err := cycle.Find(ctx, initial, func(s seafloorState) seafloorState {
+		cells := seafloor.Grid().Cells
+		for i := range cells {
+			cells[i] = rune(s.cells[i])
+		}
+		seafloor.Steps = s.steps
+		seafloor.Step()
+
+		traceMap(seafloor.Steps, seafloor.Grid())
+		if err := checkpoint.Save(ctx, seafloor.Steps, seafloor); err != nil && saveErr == nil {
+			saveErr = err
+		}
+		return seafloorState{steps: seafloor.Steps, cells: string(seafloor.Grid().Cells)}
+	}, func(s seafloorState) [sha256.Size]byte {
+		return sha256.Sum256([]byte(s.cells))
+	}, -1)
+	if err != nil {
+		if saveErr == nil {
+			saveErr = checkpoint.Save(ctx, seafloor.Steps, seafloor)
+		}
+		if saveErr != nil {
+			return 0, saveErr
+		}
+		return 0, err
+	}
+	if saveErr != nil {
+		return 0, saveErr
+	}
+	start := history.States[history.Cycle.Start].steps
+	if history.Cycle.Length != 1 {
+		return 0, fmt.Errorf("the sea cucumbers never stop: from step %d they repeat every %d steps", start, history.Cycle.Length)
+	}
+
+	return start + 1, nil
+}
+
+// seafloorState is the seafloor after some number of steps. Every cell is
+// one of the map's ASCII characters, so cells has a byte for each.
+type seafloorState struct {
+	steps int
+	cells string
+}
+
+// seafloor is the cucumbers' automaton, saved in checkpoints as the rows of
+// its map.
+type seafloor struct {
+	*grid.Automaton[rune]
+}
+
+// newSeafloor moves the east facing herd first, then the south facing one,
+// over a seafloor that wraps around.
+func newSeafloor(m *grid.Grid[rune]) *seafloor {
+	return &seafloor{grid.NewAutomaton(m, grid.Toroidal,
+		herdMoves(EastboundCucumber, grid.East),
+		herdMoves(SouthboundCucumber, grid.South),
+	)}
+}
+
+func (s *seafloor) Checkpoint() any {
+	return strings.Split(formatMap(s.Grid()), "\n")
+}
+
+func (s *seafloor) Restore(data json.RawMessage) error {
+	rows := []string{}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return err
+	}
+	m, err := parseMap(rows)
+	if err != nil {
+		return err
+	}
+	*s = *newSeafloor(m)
+	return nil
+}
+
+func formatMap(m *grid.Grid[rune]) string {
+	return m.Format(func(r rune) string { return string(r) })
+}
+
+// traceMap shows the seafloor after a number of steps, under
+// `--debug seafloor.map`.
+func traceMap(steps int, m *grid.Grid[rune]) {
+	if !tracer.Enabled(slog.LevelDebug, "seafloor.map") {
+		return
+	}
+	tracer.Debug("seafloor.map", "seafloor", "steps", steps, "map", formatMap(m))
+}
+
+func parseMap(lines []string) (*grid.Grid[rune], error) {
+	return grid.Parse(lines, func(r rune) (rune, bool) {
+		return r, r == EastboundCucumber || r == SouthboundCucumber || r == Empty
+	}, "one of `>`, `v` or `.`")
+}
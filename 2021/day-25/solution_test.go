@@ -0,0 +1,37 @@
+package day25
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Takadimi/aoc/grid"
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseMap(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		m, err := parseMap(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkMap(m); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkMap makes sure the map has somewhere on it and nothing but sea
+// cucumbers and empty spaces.
+func checkMap(m *grid.Grid[rune]) error {
+	if m.Width == 0 || m.Height == 0 {
+		return fmt.Errorf("%dx%d map", m.Width, m.Height)
+	}
+	for _, p := range m.Points() {
+		if r := m.Get(p); r != EastboundCucumber && r != SouthboundCucumber && r != Empty {
+			return fmt.Errorf("%q at %d,%d", r, p.X, p.Y)
+		}
+	}
+	return nil
+}
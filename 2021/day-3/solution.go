@@ -1,45 +1,70 @@
-package main
+package day3
 
 import (
-	"bufio"
-	"flag"
 	"fmt"
-	"os"
 	"strconv"
+	"strings"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
 )
 
-var inputFile = flag.String("inputFile", "sample1.txt", "Relative file path to use as input.")
+func init() {
+	runner.Register(runner.Day{
+		Year: 2021,
+		Day:  3,
+		Parts: []runner.PartFunc{
+			runner.Solve(parseDiagnosticReport, partOne),
+			runner.Solve(parseDiagnosticReport, partTwo),
+		},
+	})
+}
 
-func lines(fileName string) ([]string, error) {
-	f, err := os.Open(fileName)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-
-	scanner := bufio.NewScanner(f)
-	scanner.Split(bufio.ScanLines)
-	result := []string{}
-	for scanner.Scan() {
-		line := scanner.Text()
-		result = append(result, line)
-	}
+type diagnosticReport struct {
+	Numbers  []int64
+	BitWidth int
+}
+
+func partOne(report diagnosticReport) int64 {
+	gammaRate := calculateGammaRate(report.Numbers, report.BitWidth)
+	epsilonRate := calculateEpsilonRate(gammaRate, report.BitWidth)
+
+	return gammaRate * epsilonRate
+}
 
-	return result, nil
+func partTwo(report diagnosticReport) int64 {
+	oxygenGeneratorRating := calculateOxygenGeneratorRating(report.Numbers, report.BitWidth)
+	carbonDioxideScrubberRating := calculateCarbonDioxideScrubberRating(report.Numbers, report.BitWidth)
+
+	return oxygenGeneratorRating * carbonDioxideScrubberRating
+}
+
+func parseDiagnosticReport(lines []string) (diagnosticReport, error) {
+	numbers, bitWidth, err := parseLines(lines)
+	return diagnosticReport{numbers, bitWidth}, err
 }
 
 func parseLines(lines []string) ([]int64, int, error) {
+	if len(lines) == 0 || lines[0] == "" {
+		return nil, 0, input.Expected(0, 0, "a binary number")
+	}
+	bitWidth := len(lines[0])
+
 	ints := []int64{}
-	for _, l := range lines {
+	for i, l := range lines {
+		if notBinary := strings.IndexFunc(l, func(r rune) bool { return r != '0' && r != '1' }); notBinary >= 0 {
+			return ints, 0, input.Expected(i, notBinary+1, "a binary digit")
+		}
+		if len(l) != bitWidth {
+			return ints, 0, input.Expected(i, 0, fmt.Sprintf("%d bits like the first number", bitWidth))
+		}
 		asInt, err := strconv.ParseInt(l, 2, 64)
 		if err != nil {
-			return ints, 0, err
+			return ints, 0, input.ExpectedErr(i, 0, "a binary number", err)
 		}
 		ints = append(ints, asInt)
 	}
 
-	bitWidth := len(lines[0])
-
 	return ints, bitWidth, nil
 }
 
@@ -138,32 +163,3 @@ func calculateCarbonDioxideScrubberRating(numbers []int64, bitWidth int) int64 {
 
 	return -1
 }
-
-func main() {
-	flag.Parse()
-
-	lines, err := lines(*inputFile)
-	if err != nil {
-		panic(err)
-	}
-
-	numbers, bitWidth, err := parseLines(lines)
-	if err != nil {
-		panic(err)
-	}
-
-	gammaRate := calculateGammaRate(numbers, bitWidth)
-	fmt.Printf("%d (%012b)\n", gammaRate, gammaRate)
-	epsilonRate := calculateEpsilonRate(gammaRate, bitWidth)
-	fmt.Printf("%d (%012b)\n", epsilonRate, epsilonRate)
-
-	fmt.Printf("Power consumption = %d * %d = %d\n", gammaRate, epsilonRate, gammaRate*epsilonRate)
-
-	oxygenGeneratorRating := calculateOxygenGeneratorRating(numbers, bitWidth)
-	fmt.Printf("%d (%012b)\n", oxygenGeneratorRating, oxygenGeneratorRating)
-
-	carbonDioxideScrubberRating := calculateCarbonDioxideScrubberRating(numbers, bitWidth)
-	fmt.Printf("%d (%012b)\n", carbonDioxideScrubberRating, carbonDioxideScrubberRating)
-
-	fmt.Printf("Life support rating = %d * %d = %d\n", oxygenGeneratorRating, carbonDioxideScrubberRating, oxygenGeneratorRating*carbonDioxideScrubberRating)
-}
@@ -0,0 +1,36 @@
+package day3
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseDiagnosticReport(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		report, err := parseDiagnosticReport(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkDiagnosticReport(report); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkDiagnosticReport makes sure every number fits in the report's width,
+// which the ratings count bits by.
+func checkDiagnosticReport(report diagnosticReport) error {
+	if len(report.Numbers) == 0 || report.BitWidth < 1 || report.BitWidth > 63 {
+		return fmt.Errorf("%d numbers of %d bits", len(report.Numbers), report.BitWidth)
+	}
+	for i, n := range report.Numbers {
+		if n < 0 || n >= 1<<report.BitWidth {
+			return fmt.Errorf("number %d is %b, wider than %d bits", i, n, report.BitWidth)
+		}
+	}
+	return nil
+}
@@ -1,76 +1,137 @@
-package main
+package day4
 
 import (
-	"bufio"
 	"errors"
-	"flag"
 	"fmt"
-	"os"
+	"math/rand"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/seq"
 )
 
-var inputFile = flag.String("inputFile", "sample1.txt", "Relative file path to use as input.")
+func init() {
+	runner.Register(runner.Day{
+		Year: 2021,
+		Day:  4,
+		Parts: []runner.PartFunc{
+			runner.SolveErr(parseBingo, partOne),
+			runner.SolveErr(parseBingo, partTwo),
+		},
+		Generate: generateBingo,
+	})
+}
 
-func lines(fileName string) ([]string, error) {
-	f, err := os.Open(fileName)
-	if err != nil {
-		return nil, err
+var errNoWinner = errors.New("no board won with the numbers drawn")
+
+func partOne(game bingo) (int, error) {
+	firstWinner, _ := playBingo(game)
+	if firstWinner == nil {
+		return 0, errNoWinner
 	}
-	defer f.Close()
-
-	scanner := bufio.NewScanner(f)
-	scanner.Split(bufio.ScanLines)
-	result := []string{}
-	for scanner.Scan() {
-		line := scanner.Text()
-		result = append(result, line)
+	return firstWinner.Score(), nil
+}
+
+func partTwo(game bingo) (int, error) {
+	_, lastWinner := playBingo(game)
+	if lastWinner == nil {
+		return 0, errNoWinner
 	}
+	return lastWinner.Score(), nil
+}
 
-	return result, nil
+type bingo struct {
+	NumbersToDraw []int
+	Boards        []board
 }
 
-func splitByEmptyLine(lines []string) [][]string {
-	byEmptyLine := [][]string{}
-	currentLine := []string{}
-	for _, l := range lines {
-		if len(l) == 0 {
-			byEmptyLine = append(byEmptyLine, currentLine)
-			currentLine = []string{}
-			continue
+// generateBingo writes a game of size boards. The numbers go from 0 to 99
+// like the real game, or further for more boards than that, and every one
+// of them is drawn, so every board wins eventually and some at once.
+func generateBingo(r *rand.Rand, size int) []string {
+	pool := 100
+	if size > pool {
+		pool = size
+	}
+	numbers := []string{}
+	for _, n := range r.Perm(pool) {
+		numbers = append(numbers, strconv.Itoa(n))
+	}
+
+	lines := []string{strings.Join(numbers, ",")}
+	for b := 0; b < size; b++ {
+		lines = append(lines, "")
+		squares := r.Perm(pool)[:25]
+		for y := 0; y < 5; y++ {
+			row := []string{}
+			for _, n := range squares[y*5 : y*5+5] {
+				row = append(row, fmt.Sprintf("%2d", n))
+			}
+			lines = append(lines, strings.Join(row, " "))
 		}
-		currentLine = append(currentLine, l)
 	}
-	byEmptyLine = append(byEmptyLine, currentLine)
+	return lines
+}
+
+func parseBingo(lines []string) (bingo, error) {
+	sections := input.Sections(lines)
+	if len(sections) < 2 {
+		return bingo{}, input.Expected(len(lines)-1, 0, "a blank line followed by at least one board after the numbers to draw")
+	}
+
+	numbersToDraw, err := parseNumbersToDraw(sections[0])
+	if err != nil {
+		return bingo{}, err
+	}
 
-	return byEmptyLine
+	boards, err := parseBoards(sections[1:])
+	if err != nil {
+		return bingo{}, err
+	}
+
+	return bingo{NumbersToDraw: numbersToDraw, Boards: boards}, nil
 }
 
-func numbersFromFields(fields []string) ([]int, error) {
-	numbers := []int{}
-	for _, ns := range fields {
-		n, err := strconv.Atoi(ns)
-		if err != nil {
-			return nil, err
+func playBingo(game bingo) (*board, *board) {
+	boards := game.Boards
+
+	var firstWinner *board
+	var lastWinner *board
+	for _, n := range game.NumbersToDraw {
+		winners, remaining := checkBoards(boards, n)
+		if len(winners) > 0 {
+			if firstWinner == nil {
+				firstWinner = &winners[0]
+			}
+			if len(remaining) == 0 {
+				lastWinner = &winners[0]
+			}
 		}
-		numbers = append(numbers, n)
+		boards = remaining
 	}
 
-	return numbers, nil
+	return firstWinner, lastWinner
 }
 
-func parseNumbersToDraw(section []string) ([]int, error) {
-	if len(section) != 1 {
-		return nil, errors.New("invalid lines submitted")
+func parseNumbersToDraw(section input.Section) ([]int, error) {
+	if len(section.Lines) != 1 {
+		return nil, section.Expected(1, 0, "a single line of numbers to draw followed by a blank line")
 	}
-	numbersLine := section[0]
+	numbersLine := section.Lines[0]
 	fields := strings.Split(numbersLine, ",")
 
-	return numbersFromFields(fields)
+	numbers, err := seq.MapErr(fields, strconv.Atoi)
+	var fieldErr *seq.IndexError
+	if errors.As(err, &fieldErr) {
+		return nil, section.ExpectedErr(0, input.SplitColumn(numbersLine, ",", fieldErr.Index), "a number to draw", fieldErr.Err)
+	}
+	return numbers, nil
 }
 
-func parseBoards(sections [][]string) ([]board, error) {
+func parseBoards(sections []input.Section) ([]board, error) {
 	currentBoard := board{
 		Squares: make([][]square, 0),
 	}
@@ -81,10 +142,17 @@ func parseBoards(sections [][]string) ([]board, error) {
 			Squares: make([][]square, 0),
 		}
 
-		for y, line := range section {
-			lineNumbers, err := parseBoardLineNumbers(line)
-			if err != nil {
-				return nil, err
+		if len(section.Lines) == 0 {
+			return nil, section.Expected(0, 0, "a board")
+		}
+		for y, line := range section.Lines {
+			lineNumbers, err := seq.MapErr(strings.Fields(line), strconv.Atoi)
+			var fieldErr *seq.IndexError
+			if errors.As(err, &fieldErr) {
+				return nil, section.ExpectedErr(y, input.FieldColumn(line, fieldErr.Index), "a board number", fieldErr.Err)
+			}
+			if len(lineNumbers) == 0 || (y > 0 && len(lineNumbers) != len(currentBoard.Squares[0])) {
+				return nil, section.Expected(y, 0, "a row as wide as the board's first row")
 			}
 
 			row := []square{}
@@ -104,10 +172,6 @@ func parseBoards(sections [][]string) ([]board, error) {
 	return boards, nil
 }
 
-func parseBoardLineNumbers(line string) ([]int, error) {
-	return numbersFromFields(strings.Fields(line))
-}
-
 type board struct {
 	Squares          [][]square
 	LastMarkedSquare *square
@@ -226,46 +290,3 @@ func checkBoards(boards []board, number int) ([]board, []board) {
 
 	return winners, remaining
 }
-
-func main() {
-	flag.Parse()
-
-	lines, err := lines(*inputFile)
-	if err != nil {
-		panic(err)
-	}
-
-	sections := splitByEmptyLine(lines)
-	if len(sections) < 2 {
-		fmt.Println("Expected one section of numbers to draw and at least one section of a board")
-		os.Exit(1)
-	}
-
-	numbersToDraw, err := parseNumbersToDraw(sections[0])
-	if err != nil {
-		panic(err)
-	}
-
-	boards, err := parseBoards(sections[1:])
-	if err != nil {
-		panic(err)
-	}
-
-	var firstWinner *board
-	var lastWinner *board
-	for _, n := range numbersToDraw {
-		winners, remaining := checkBoards(boards, n)
-		if len(winners) > 0 {
-			if firstWinner == nil {
-				firstWinner = &winners[0]
-			}
-			if len(remaining) == 0 {
-				lastWinner = &winners[0]
-			}
-		}
-		boards = remaining
-	}
-
-	fmt.Printf("First Winner (Score: %d):\n%s\n", firstWinner.Score(), firstWinner)
-	fmt.Printf("Last Winner (Score: %d):\n%s\n", lastWinner.Score(), lastWinner)
-}
@@ -0,0 +1,47 @@
+package day4
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseBingo(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		game, err := parseBingo(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkBingo(game); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkBingo makes sure every board is a rectangle whose squares know where
+// they are, since a win is checked along a marked square's row and column.
+func checkBingo(game bingo) error {
+	if len(game.Boards) == 0 {
+		return errors.New("no boards")
+	}
+	for i, b := range game.Boards {
+		if len(b.Squares) == 0 || len(b.Squares[0]) == 0 {
+			return fmt.Errorf("board %d is empty", i)
+		}
+		for y, row := range b.Squares {
+			if len(row) != len(b.Squares[0]) {
+				return fmt.Errorf("board %d row %d has %d squares, not %d", i, y, len(row), len(b.Squares[0]))
+			}
+			for x, s := range row {
+				if s.Position != (position{X: x, Y: y}) {
+					return fmt.Errorf("board %d square at %d,%d thinks it's at %d,%d", i, x, y, s.Position.X, s.Position.Y)
+				}
+			}
+		}
+	}
+	return nil
+}
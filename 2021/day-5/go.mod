@@ -1,3 +0,0 @@
-module github.com/Takadimi/aoc-2021-day-5
-
-go 1.18
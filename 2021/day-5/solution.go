@@ -0,0 +1,170 @@
+package day5
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/seq"
+	"github.com/Takadimi/aoc/set"
+)
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2021,
+		Day:  5,
+		Parts: []runner.PartFunc{
+			runner.Solve(parseLines, partOne),
+			runner.Solve(parseLines, partTwo),
+		},
+		Generate: generateVentLines,
+	})
+}
+
+func partOne(lines []line) int {
+	return countOfPointsVisitedMultipleTimes(seq.Filter(lines, isStraight))
+}
+
+func partTwo(lines []line) int {
+	return countOfPointsVisitedMultipleTimes(lines)
+}
+
+type line struct {
+	A, B point
+}
+
+type point struct {
+	X, Y int
+}
+
+// parsePoint parses the nth field of line i as an x,y coordinate.
+func parsePoint(i int, textLine string, n int) (p point, err error) {
+	column := input.FieldColumn(textLine, n)
+	pointText := strings.Fields(textLine)[n]
+
+	parts := strings.Split(pointText, ",")
+	if len(parts) != 2 {
+		return p, input.Expected(i, column, "a coordinate like x,y")
+	}
+	x, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return p, input.ExpectedErr(i, column, "an x coordinate", err)
+	}
+	y, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return p, input.ExpectedErr(i, column+input.SplitColumn(pointText, ",", 1)-1, "a y coordinate", err)
+	}
+
+	return point{x, y}, nil
+}
+
+// generateVentLines writes size lines of vents across a floor 2*size wide,
+// each horizontal, vertical or diagonal at 45 degrees like the real ones.
+func generateVentLines(r *rand.Rand, size int) []string {
+	extent := 2 * size
+	lines := []string{}
+	for i := 0; i < size; i++ {
+		a := point{r.Intn(extent), r.Intn(extent)}
+		b := a
+		switch r.Intn(3) {
+		case 0:
+			b.X = r.Intn(extent)
+		case 1:
+			b.Y = r.Intn(extent)
+		default:
+			// only as far as the floor goes in the diagonal's direction
+			dx, dy := 1-2*r.Intn(2), 1-2*r.Intn(2)
+			room := func(at, d int) int {
+				if d > 0 {
+					return extent - 1 - at
+				}
+				return at
+			}
+			steps := room(a.X, dx)
+			if roomY := room(a.Y, dy); roomY < steps {
+				steps = roomY
+			}
+			steps = r.Intn(steps + 1)
+			b = point{a.X + dx*steps, a.Y + dy*steps}
+		}
+		lines = append(lines, fmt.Sprintf("%d,%d -> %d,%d", a.X, a.Y, b.X, b.Y))
+	}
+	return lines
+}
+
+func parseLines(textLines []string) (lines []line, err error) {
+	for i, tl := range textLines {
+		fields := strings.Fields(tl)
+		if len(fields) != 3 || fields[1] != "->" {
+			return lines, input.Expected(i, 0, "a line like `x1,y1 -> x2,y2`")
+		}
+
+		a, err := parsePoint(i, tl, 0)
+		if err != nil {
+			return lines, err
+		}
+		b, err := parsePoint(i, tl, 2)
+		if err != nil {
+			return lines, err
+		}
+
+		lines = append(lines, line{a, b})
+	}
+
+	return lines, nil
+}
+
+func isStraight(l line) bool {
+	return l.A.X == l.B.X || l.A.Y == l.B.Y
+}
+
+func extrapolatePoints(l line) (points []point) {
+	// 0,9 -> 5,9
+	// ----------
+	// 0,9
+	// 1,9
+	// 2,9
+	// 3,9
+	// 4,9
+	// 5,9
+
+	cp := l.A
+	for {
+		points = append(points, cp)
+
+		if cp.X == l.B.X && cp.Y == l.B.Y {
+			break
+		}
+
+		if cp.X < l.B.X {
+			cp.X++
+		} else if cp.X > l.B.X {
+			cp.X--
+		}
+
+		if cp.Y < l.B.Y {
+			cp.Y++
+		} else if cp.Y > l.B.Y {
+			cp.Y--
+		}
+	}
+
+	return points
+}
+
+func visitedPoints(lines []line) *set.Counter[point] {
+	visited := set.NewCounter[point]()
+	for _, l := range lines {
+		for _, p := range extrapolatePoints(l) {
+			visited.Add(p, 1)
+		}
+	}
+	return visited
+}
+
+func countOfPointsVisitedMultipleTimes(lines []line) int {
+	return visitedPoints(lines).Repeated()
+}
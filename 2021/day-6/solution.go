@@ -0,0 +1,215 @@
+package day6
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Takadimi/aoc/checkpoint"
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/matrix"
+	"github.com/Takadimi/aoc/runner"
+)
+
+var flags = flag.NewFlagSet("2021/day-6", flag.ContinueOnError)
+var daysFlag = flags.Int("days", 256, "Count the fish after this many days in part two.")
+var modFlag = flags.Int("mod", 0, "Count the fish modulo this, for horizons whose counts don't fit in an int.")
+
+func init() {
+	runner.Register(runner.Day{
+		Year:  2021,
+		Day:   6,
+		Flags: flags,
+		Parts: []runner.PartFunc{
+			runner.SolveCtx(parseInitialNumbers, partOne),
+			runner.SolveErr(parseInitialNumbers, partTwo),
+		},
+	})
+}
+
+func partOne(ctx context.Context, initialNumbers []int) (int, error) {
+	return fishOverDays(ctx, initialNumbers, 80)
+}
+
+func partTwo(initialNumbers []int) (int, error) {
+	if *daysFlag < 0 || *modFlag < 0 {
+		return 0, fmt.Errorf("--days and --mod can't be negative")
+	}
+	return fishOverDaysByMatrix(initialNumbers, *daysFlag, *modFlag)
+}
+
+// school is how many fish there are at each point of the clock, saved
+// whole in checkpoints.
+type school struct {
+	Clock [9]int
+}
+
+func (s *school) Checkpoint() any {
+	return s
+}
+
+func (s *school) Restore(data json.RawMessage) error {
+	return json.Unmarshal(data, s)
+}
+
+func fishOverDays(ctx context.Context, initialNumbers []int, days int) (int, error) {
+	fish := &school{}
+	for _, n := range initialNumbers {
+		fish.Clock[n] = fish.Clock[n] + 1
+	}
+	start, _, err := checkpoint.Resume(ctx, fish)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := start; i < days; i++ {
+		if err := ctx.Err(); err != nil {
+			if saveErr := checkpoint.Save(ctx, i, fish); saveErr != nil {
+				return 0, saveErr
+			}
+			return 0, err
+		}
+
+		oldClock := fish.Clock
+		fish.Clock = [9]int{}
+		for day := range oldClock {
+			if day == 0 {
+				fish.Clock[6] += oldClock[day]
+				fish.Clock[8] += oldClock[day]
+				continue
+			}
+
+			fish.Clock[day-1] += oldClock[day]
+		}
+
+		if err := checkpoint.Save(ctx, i+1, fish); err != nil {
+			return 0, err
+		}
+	}
+
+	totalFish := 0
+	for _, daySum := range fish.Clock {
+		totalFish += daySum
+	}
+
+	return totalFish, nil
+}
+
+// clockTransition moves the fish clock on a day: every timer counts down,
+// and fish at 0 go back to 6 while adding as many new fish at 8.
+var clockTransition = func() *matrix.Matrix {
+	t := matrix.New(9, 9)
+	for day := 1; day < 9; day++ {
+		t.Set(day-1, day, 1)
+	}
+	t.Set(6, 0, 1)
+	t.Set(8, 0, 1)
+	return t
+}()
+
+// fishOverDaysByMatrix is fishOverDays raising the day's transition to the
+// power of days, so far horizons only take a few dozen matrix products.
+// With a positive mod the count is modulo mod.
+func fishOverDaysByMatrix(initialNumbers []int, days, mod int) (int, error) {
+	clock := make([]int, 9)
+	for _, n := range initialNumbers {
+		clock[n]++
+	}
+
+	if mod > 0 {
+		clock = matrix.ApplyMod(matrix.PowMod(clockTransition, days, mod), clock, mod)
+		totalFish := 0
+		for _, daySum := range clock {
+			totalFish = (totalFish + daySum) % mod
+		}
+		return totalFish, nil
+	}
+
+	transition, err := matrix.Pow(clockTransition, days)
+	if err == nil {
+		clock, err = matrix.Apply(transition, clock)
+	}
+	totalFish := 0
+	for _, daySum := range clock {
+		if totalFish+daySum < totalFish {
+			err = matrix.ErrOverflow
+		}
+		totalFish += daySum
+	}
+	if errors.Is(err, matrix.ErrOverflow) {
+		return 0, fmt.Errorf("too many fish to count after %d days, try --mod: %w", days, err)
+	}
+	return totalFish, err
+}
+
+func parseInitialNumbers(lines []string) ([]int, error) {
+	if len(lines) == 0 {
+		return nil, input.Expected(0, 0, "a line of timers")
+	}
+	if len(lines) > 1 {
+		return nil, input.Expected(1, 0, "only one line of input")
+	}
+	parts := strings.Split(lines[0], ",")
+	numbers := []int{}
+	for i, numberString := range parts {
+		n, err := strconv.Atoi(numberString)
+		if err != nil || n < 0 || n > 8 {
+			return nil, input.ExpectedErr(0, input.SplitColumn(lines[0], ",", i), "a timer of 0-8", err)
+		}
+		numbers = append(numbers, int(n))
+	}
+	return numbers, nil
+}
+
+/*
+   Starting point: 3
+   After 18d, this would generate 3 new fish
+
+   18 - 3 = 15d -- +1 fish
+
+   15 / 6 = 2.5 = 2 -- +2 fish
+
+   Starting point: 4
+   After 18d, this would generate 3 new fish
+
+   18 - 4 = 14d  -- +1 fish
+   14 / 6 = 2.3 = 2 -- +2 fish
+
+   Starting point: 3
+   After 18d, this would generate 3 new fish
+
+   18 - 3 = 15d -- +1 fish
+
+   15 / 6 = 2.5 = 2 -- +2 fish
+
+   Starting point: 1
+   After 18d, this would generate 3 new fish
+
+   18 - 1 = 17d -- +1 fish
+
+   17 / 6 = 2.83 = 2 -- +2 fish
+
+   Starting point: 2
+   After 18d, this would generate 3 new fish
+
+   18 - 2 = 16d -- +1 fish
+
+   16 / 6 = 2.6 = 2 -- +2 fish
+
+   ---
+
+   What's the freakin' formulaaaa?
+
+   1 fish starting at 3
+   18 - 3 = 15 -- +1 fish
+   15 - 6 = 9 -- +1 fish
+   9 - 6 = 3 -- +1 fish
+   3 - 6 = -6 -- +0 fish
+
+   floor(15 / 6) = 2
+   2 * (15/8) = 4
+*/
@@ -0,0 +1,32 @@
+package day6
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseInitialNumbers(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		numbers, err := parseInitialNumbers(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkInitialNumbers(numbers); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkInitialNumbers makes sure every timer has a place on the clock.
+func checkInitialNumbers(numbers []int) error {
+	for i, n := range numbers {
+		if n < 0 || n >= len(school{}.Clock) {
+			return fmt.Errorf("timer %d is %d", i, n)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package day7
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/memo"
+	"github.com/Takadimi/aoc/parallel"
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/seq"
+)
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2021,
+		Day:  7,
+		Parts: []runner.PartFunc{
+			runner.SolveCtx(parseCrabPositions, partOne),
+			runner.SolveCtx(parseCrabPositions, partTwo),
+		},
+		Variants: []runner.Variant{
+			{Name: "serial", Part: 1, Func: runner.Solve(parseCrabPositions, partOneSerial)},
+			{Name: "median", Part: 1, Func: runner.Solve(parseCrabPositions, partOneByMedian)},
+			{Name: "serial", Part: 2, Func: runner.Solve(parseCrabPositions, partTwoSerial)},
+			{Name: "mean", Part: 2, Func: runner.Solve(parseCrabPositions, partTwoByMean)},
+		},
+		Generate: generateCrabPositions,
+	})
+}
+
+func partOne(ctx context.Context, crabPositions []int) (int, error) {
+	return cheapestFuelCost(ctx, crabPositions, func(targetPosition int) int {
+		return totalFuelCostForPositionAtConstantBurn(crabPositions, targetPosition)
+	})
+}
+
+func partTwo(ctx context.Context, crabPositions []int) (int, error) {
+	highestPos, _ := seq.Max(crabPositions)
+	burnCosts := incrementalBurnCosts(highestPos)
+	return cheapestFuelCost(ctx, crabPositions, func(targetPosition int) int {
+		return totalFuelCostForPositionAtIncrementalBurn(crabPositions, targetPosition, burnCosts)
+	})
+}
+
+// cheapestFuelCost tries every target position from 0 to the furthest crab
+// at once, since each costs the same to work out as any other.
+func cheapestFuelCost(ctx context.Context, crabPositions []int, fuelCost func(targetPosition int) int) (int, error) {
+	highestPos, _ := seq.Max(crabPositions)
+	return parallel.MapReduce(ctx, highestPos+1, fuelCost, func(cheapest, cost int) int {
+		if cost < cheapest {
+			return cost
+		}
+		return cheapest
+	}, math.MaxInt)
+}
+
+func partOneSerial(crabPositions []int) int {
+	highestPos, _ := seq.Max(crabPositions)
+	cheapestFuelCost := totalFuelCostForPositionAtConstantBurn(crabPositions, 0)
+	for i := 1; i <= highestPos; i++ {
+		fuelCost := totalFuelCostForPositionAtConstantBurn(crabPositions, i)
+		if fuelCost < cheapestFuelCost {
+			cheapestFuelCost = fuelCost
+		}
+	}
+
+	return cheapestFuelCost
+}
+
+func partTwoSerial(crabPositions []int) int {
+	highestPos, _ := seq.Max(crabPositions)
+	burnCosts := incrementalBurnCosts(highestPos)
+	cheapestFuelCost := totalFuelCostForPositionAtIncrementalBurn(crabPositions, 0, burnCosts)
+	for i := 1; i <= highestPos; i++ {
+		fuelCost := totalFuelCostForPositionAtIncrementalBurn(crabPositions, i, burnCosts)
+		if fuelCost < cheapestFuelCost {
+			cheapestFuelCost = fuelCost
+		}
+	}
+
+	return cheapestFuelCost
+}
+
+// partOneByMedian moves every crab to the median position. Moving the target
+// past a crab towards the middle saves more crabs a step than it costs, so
+// no target does better.
+func partOneByMedian(crabPositions []int) int {
+	sorted := append([]int(nil), crabPositions...)
+	sort.Ints(sorted)
+	return totalFuelCostForPositionAtConstantBurn(crabPositions, sorted[len(sorted)/2])
+}
+
+// partTwoByMean moves every crab to near the mean position. With each step
+// costing one more than the last, the total cost is within half a crab of
+// being the squared distance from the target, which is least at the mean, so
+// the best whole target is one of the two around it.
+func partTwoByMean(crabPositions []int) int {
+	mean := seq.Sum(crabPositions) / len(crabPositions)
+	cheapestFuelCost := -1
+	for _, target := range []int{mean, mean + 1} {
+		fuelCost := 0
+		for _, position := range crabPositions {
+			distance := int(math.Abs(float64(position - target)))
+			fuelCost += distance * (distance + 1) / 2
+		}
+		if cheapestFuelCost < 0 || fuelCost < cheapestFuelCost {
+			cheapestFuelCost = fuelCost
+		}
+	}
+	return cheapestFuelCost
+}
+
+func totalFuelCostForPositionAtConstantBurn(crabPositions []int, targetPosition int) int {
+	totalFuelCost := 0
+	for _, position := range crabPositions {
+		totalFuelCost += int(math.Abs(float64(position - targetPosition)))
+	}
+
+	return totalFuelCost
+}
+
+// incrementalBurnCosts is the fuel it takes to move each distance up to
+// maxDistance when every step costs one more than the last.
+func incrementalBurnCosts(maxDistance int) []int {
+	return memo.Table(maxDistance+1, func(distance int, costs []int) int {
+		if distance == 0 {
+			return 0
+		}
+		return costs[distance-1] + distance
+	})
+}
+
+func totalFuelCostForPositionAtIncrementalBurn(crabPositions []int, targetPosition int, burnCosts []int) int {
+	totalFuelCost := 0
+	for _, position := range crabPositions {
+		distance := int(math.Abs(float64(position - targetPosition)))
+		totalFuelCost += burnCosts[distance]
+	}
+
+	return totalFuelCost
+}
+
+func generateCrabPositions(r *rand.Rand, size int) []string {
+	positions := []string{}
+	for i := 0; i <= size; i++ {
+		positions = append(positions, strconv.Itoa(r.Intn(2*size+1)))
+	}
+	return []string{strings.Join(positions, ",")}
+}
+
+func parseCrabPositions(lines []string) ([]int, error) {
+	if len(lines) == 0 {
+		return nil, input.Expected(0, 0, "a line of crab positions")
+	}
+	parts := strings.Split(lines[0], ",")
+	positions := []int{}
+	for i, p := range parts {
+		position, err := strconv.Atoi(p)
+		if err != nil || position < 0 {
+			return nil, input.ExpectedErr(0, input.SplitColumn(lines[0], ",", i), "a crab position", err)
+		}
+		positions = append(positions, position)
+	}
+
+	return positions, nil
+}
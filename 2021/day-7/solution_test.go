@@ -0,0 +1,80 @@
+package day7
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+// realCrabPositions parses the real input for a benchmark, skipping it if
+// the input isn't there.
+func realCrabPositions(b *testing.B) []int {
+	b.Helper()
+	lines, err := input.Lines("input.txt")
+	if err != nil {
+		b.Skip(err)
+	}
+	crabPositions, err := parseCrabPositions(lines)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	return crabPositions
+}
+
+func BenchmarkPartOne(b *testing.B) {
+	crabPositions := realCrabPositions(b)
+	for i := 0; i < b.N; i++ {
+		if _, err := partOne(context.Background(), crabPositions); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPartOneSerial(b *testing.B) {
+	crabPositions := realCrabPositions(b)
+	for i := 0; i < b.N; i++ {
+		partOneSerial(crabPositions)
+	}
+}
+
+func BenchmarkPartOneByMedian(b *testing.B) {
+	crabPositions := realCrabPositions(b)
+	for i := 0; i < b.N; i++ {
+		partOneByMedian(crabPositions)
+	}
+}
+
+func BenchmarkPartTwo(b *testing.B) {
+	crabPositions := realCrabPositions(b)
+	for i := 0; i < b.N; i++ {
+		if _, err := partTwo(context.Background(), crabPositions); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPartTwoSerial(b *testing.B) {
+	crabPositions := realCrabPositions(b)
+	for i := 0; i < b.N; i++ {
+		partTwoSerial(crabPositions)
+	}
+}
+
+func BenchmarkPartTwoByMean(b *testing.B) {
+	crabPositions := realCrabPositions(b)
+	for i := 0; i < b.N; i++ {
+		partTwoByMean(crabPositions)
+	}
+}
+
+func FuzzParseCrabPositions(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		// any input should be parsed or rejected, never panicked on
+		parseCrabPositions(input.SplitLines(text))
+	})
+}
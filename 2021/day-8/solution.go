@@ -0,0 +1,156 @@
+package day8
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Takadimi/aoc/combin"
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
+)
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2021,
+		Day:  8,
+		Parts: []runner.PartFunc{
+			runner.Solve(parseEntries, partOne),
+			runner.SolveErr(parseEntries, partTwo),
+		},
+	})
+}
+
+var uniqueDigitCount = map[int]int{
+	2: 1,
+	4: 4,
+	3: 7,
+	7: 8,
+}
+
+// entry is one display: the ten unique signal patterns and the four digits
+// of its output value.
+type entry struct {
+	Patterns []string
+	Output   []string
+}
+
+func partOne(entries []entry) int {
+	sum := 0
+	for _, e := range entries {
+		sum += instancesOfUniqueDigits(e)
+	}
+	return sum
+}
+
+func parseEntries(lines []string) ([]entry, error) {
+	entries := []entry{}
+	for i, l := range lines {
+		parts := strings.Split(l, "|")
+		if len(parts) != 2 {
+			return nil, input.Expected(i, 0, "signal patterns and an output value separated by `|`")
+		}
+		patterns := strings.Fields(parts[0])
+		if len(patterns) != 10 {
+			return nil, input.Expected(i, 0, "ten signal patterns before `|`")
+		}
+		output := strings.Fields(parts[1])
+		if len(output) != 4 {
+			return nil, input.Expected(i, input.SplitColumn(l, "|", 1), "four output digits after `|`")
+		}
+		for n, f := range append(patterns, output...) {
+			if strings.Trim(f, "abcdefg") != "" || len(f) > 7 {
+				return nil, input.Expected(i, input.FieldColumn(strings.Replace(l, "|", " ", 1), n), "segments a-g")
+			}
+		}
+		entries = append(entries, entry{Patterns: patterns, Output: output})
+	}
+	return entries, nil
+}
+
+func instancesOfUniqueDigits(e entry) int {
+	count := 0
+	for _, f := range e.Output {
+		if _, isUnique := uniqueDigitCount[len(f)]; isUnique {
+			count++
+		}
+	}
+	return count
+}
+
+// segments is a set of lit segments, bit 0 for a through bit 6 for g.
+type segments uint8
+
+func segmentsOf(pattern string) segments {
+	var s segments
+	for _, r := range pattern {
+		s |= 1 << (r - 'a')
+	}
+	return s
+}
+
+// digitBySegments is which digit a working display shows for the segments
+// lit.
+var digitBySegments = map[segments]int{
+	segmentsOf("abcefg"):  0,
+	segmentsOf("cf"):      1,
+	segmentsOf("acdeg"):   2,
+	segmentsOf("acdfg"):   3,
+	segmentsOf("bcdf"):    4,
+	segmentsOf("abdfg"):   5,
+	segmentsOf("abdefg"):  6,
+	segmentsOf("acf"):     7,
+	segmentsOf("abcdefg"): 8,
+	segmentsOf("abcdfg"):  9,
+}
+
+func partTwo(entries []entry) (int, error) {
+	sum := 0
+	for i, e := range entries {
+		value, err := outputValue(e)
+		if err != nil {
+			return 0, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		sum += value
+	}
+	return sum, nil
+}
+
+// outputValue tries every way the seven wires could be crossed until one
+// turns all ten patterns into digits, then reads the output with it.
+func outputValue(e entry) (int, error) {
+	wires := []segments{1 << 0, 1 << 1, 1 << 2, 1 << 3, 1 << 4, 1 << 5, 1 << 6}
+
+	var wiring []segments
+	combin.Permutations(wires, func(candidate []segments) bool {
+		for _, p := range e.Patterns {
+			if _, isDigit := digitBySegments[rewire(p, candidate)]; !isDigit {
+				return true
+			}
+		}
+		wiring = append([]segments(nil), candidate...)
+		return false
+	})
+	if wiring == nil {
+		return 0, fmt.Errorf("no wiring turns every pattern into a digit")
+	}
+
+	value := 0
+	for _, o := range e.Output {
+		digit, isDigit := digitBySegments[rewire(o, wiring)]
+		if !isDigit {
+			return 0, fmt.Errorf("output %s isn't a digit with the wiring found", o)
+		}
+		value = value*10 + digit
+	}
+	return value, nil
+}
+
+// rewire is the segments a pattern lights once wire w is connected to
+// segment wiring[w].
+func rewire(pattern string, wiring []segments) segments {
+	var s segments
+	for _, r := range pattern {
+		s |= wiring[r-'a']
+	}
+	return s
+}
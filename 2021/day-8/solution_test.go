@@ -0,0 +1,39 @@
+package day8
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseEntries(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		entries, err := parseEntries(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkEntries(entries); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkEntries makes sure every entry has all ten patterns and four output
+// digits, each made of segments a-g.
+func checkEntries(entries []entry) error {
+	for i, e := range entries {
+		if len(e.Patterns) != 10 || len(e.Output) != 4 {
+			return fmt.Errorf("entry %d has %d patterns and %d output digits", i, len(e.Patterns), len(e.Output))
+		}
+		for _, f := range append(append([]string{}, e.Patterns...), e.Output...) {
+			if f == "" || len(f) > 7 || strings.Trim(f, "abcdefg") != "" {
+				return fmt.Errorf("entry %d has segments %q", i, f)
+			}
+		}
+	}
+	return nil
+}
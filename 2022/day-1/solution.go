@@ -0,0 +1,53 @@
+package day1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/seq"
+)
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2022,
+		Day:  1,
+		Parts: []runner.PartFunc{
+			runner.Solve(parseCalorieEntries, partOne),
+			runner.SolveErr(parseCalorieEntries, partTwo),
+		},
+	})
+}
+
+func partOne(caloriesByElf []int) int {
+	highest, _ := seq.Max(caloriesByElf)
+	return highest
+}
+
+func partTwo(caloriesByElf []int) (int, error) {
+	if len(caloriesByElf) < 3 {
+		return 0, fmt.Errorf("need at least three elves, got %d", len(caloriesByElf))
+	}
+	return seq.Sum(seq.TopK(caloriesByElf, 3)), nil
+}
+
+func parseCalorieEntries(calorieEntries []string) ([]int, error) {
+	caloriesByElf := []int{}
+	currentCaloriesForElf := 0
+	for i, calorieEntry := range calorieEntries {
+		if calorieEntry == "" {
+			caloriesByElf = append(caloriesByElf, currentCaloriesForElf)
+			currentCaloriesForElf = 0
+			continue
+		}
+
+		calorieCount, err := strconv.Atoi(calorieEntry)
+		if err != nil {
+			return nil, input.ExpectedErr(i, 1, "a calorie count", err)
+		}
+		currentCaloriesForElf += calorieCount
+	}
+	caloriesByElf = append(caloriesByElf, currentCaloriesForElf)
+	return caloriesByElf, nil
+}
@@ -0,0 +1,16 @@
+package day1
+
+import (
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseCalorieEntries(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		// any input should be parsed or rejected, never panicked on
+		parseCalorieEntries(input.SplitLines(text))
+	})
+}
@@ -0,0 +1,83 @@
+package day10
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/vm"
+)
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2022,
+		Day:  10,
+		Parts: []runner.PartFunc{
+			runner.SolveCtx(parseProgram, partOne),
+			runner.SolveCtx(parseProgram, partTwo),
+		},
+	})
+}
+
+// cpu is the handheld's instruction set. The X register only changes once
+// an instruction's cycles are over.
+var cpu = vm.NewISA(
+	vm.Opcode{Mnemonic: "noop", Arity: 0, Cycles: 1, Exec: func(m *vm.Machine, args []vm.Operand) int {
+		return 1
+	}},
+	vm.Opcode{Mnemonic: "addx", Arity: 1, Cycles: 2, Exec: func(m *vm.Machine, args []vm.Operand) int {
+		m.Registers["x"] += m.Value(args[0])
+		return 1
+	}},
+)
+
+func newCPU(program []vm.Instruction) *vm.Machine {
+	return vm.New(cpu, program, vm.Registers{"x": 1})
+}
+
+func partOne(ctx context.Context, program []vm.Instruction) (int, error) {
+	signalStrengthSum := 0
+
+	m := newCPU(program)
+	m.OnCycle(func(m *vm.Machine) {
+		// every 40th cycle, starting at 20, evaluate signal strength
+		if (m.Cycle-20)%40 == 0 {
+			signalStrengthSum += m.Registers["x"] * m.Cycle
+		}
+	})
+	if err := m.Run(ctx); err != nil {
+		return 0, err
+	}
+
+	return signalStrengthSum, nil
+}
+
+func partTwo(ctx context.Context, program []vm.Instruction) (string, error) {
+	renderedImage := ""
+
+	m := newCPU(program)
+	m.OnCycle(func(m *vm.Machine) {
+		// the CRT draws a pixel a cycle, lit when the three pixel wide
+		// sprite centred on X covers it
+		xPos := m.Cycle % 40
+		registerX := m.Registers["x"]
+		if registerX == xPos || registerX+1 == xPos || registerX+2 == xPos {
+			renderedImage += "#"
+		} else {
+			renderedImage += "."
+		}
+
+		if m.Cycle%40 == 0 {
+			renderedImage += "\n"
+		}
+	})
+	if err := m.Run(ctx); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(renderedImage, "\n"), nil
+}
+
+func parseProgram(lines []string) ([]vm.Instruction, error) {
+	return vm.Assemble(cpu, lines)
+}
@@ -0,0 +1,491 @@
+package day11
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/Takadimi/aoc/checkpoint"
+	"github.com/Takadimi/aoc/cycle"
+	"github.com/Takadimi/aoc/expr"
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/seq"
+	"github.com/Takadimi/aoc/trace"
+)
+
+var tracer = trace.New("2022/day-11")
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2022,
+		Day:  11,
+		Parts: []runner.PartFunc{
+			runner.SolveCtx(parseMonkeys, partOne),
+			runner.SolveCtx(parseMonkeys, partTwo),
+		},
+		Generate: generateMonkeys,
+	})
+}
+
+func partOne(ctx context.Context, monkeys []Monkey) (int, error) {
+	trajectories, err := itemTrajectories(ctx, monkeys, 20, func(worryLevel int) int {
+		return worryLevel / 3
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return monkeyBusiness(inspectionCountsAfter(trajectories, len(monkeys), 20)), nil
+}
+
+func productOfDivisors(monkeys []Monkey) int {
+	product := 1
+	for _, monkey := range monkeys {
+		product *= monkey.Divisor
+	}
+	return product
+}
+
+func partTwo(ctx context.Context, monkeys []Monkey) (int, error) {
+	// every test only cares about the worry level modulo its divisor, so
+	// keeping it modulo all of them keeps it small without changing a throw
+	divisor := productOfDivisors(monkeys)
+
+	rounds := 10_000
+	trajectories, err := itemTrajectories(ctx, monkeys, rounds, func(worryLevel int) int {
+		return worryLevel % divisor
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if tracer.Enabled(slog.LevelInfo, "monkeys.round") {
+		for _, round := range []int{1, 20, 1000} {
+			tracer.Info("monkeys.round", "inspections", "round", round, "counts", inspectionCountsAfter(trajectories, len(monkeys), round))
+		}
+	}
+
+	return monkeyBusiness(inspectionCountsAfter(trajectories, len(monkeys), rounds)), nil
+}
+
+func monkeyBusiness(inspectionCounts []int) int {
+	mostActive := seq.TopK(inspectionCounts, 2)
+	return mostActive[0] * mostActive[1]
+}
+
+// itemState is where an item is at the start of a round, along with which
+// monkeys inspected it in the round before, a bit each. An item only moves
+// on to later monkeys until it's thrown back, ending its round, so no
+// monkey inspects it twice in one.
+type itemState struct {
+	Monkey     int
+	WorryLevel int
+	Inspected  uint64
+}
+
+// maxMonkeys is as many monkeys as itemState has bits for.
+const maxMonkeys = 64
+
+type itemKey struct {
+	Monkey, WorryLevel int
+}
+
+// itemTrajectories follows every item on its own for up to the given rounds.
+// Items never affect one another, so once an item is back with the same
+// monkey at the same worry level it's going round in a loop and the rest of
+// its rounds can be extrapolated.
+func itemTrajectories(ctx context.Context, monkeys []Monkey, rounds int, relief func(int) int) ([]cycle.History[itemState], error) {
+	// an operation failing stops the item where it is, which Find sees as a
+	// cycle straight away
+	var operationErr error
+	step := func(item itemState) itemState {
+		if operationErr != nil {
+			return item
+		}
+		next := itemState{Monkey: item.Monkey, WorryLevel: item.WorryLevel}
+		for {
+			monkey := monkeys[next.Monkey]
+			next.Inspected |= 1 << next.Monkey
+			worryLevel, err := monkey.Operation(next.WorryLevel)
+			if err != nil {
+				operationErr = fmt.Errorf("monkey %d: %w", next.Monkey, err)
+				return item
+			}
+			next.WorryLevel = relief(worryLevel)
+
+			thrownToMonkeyIndex := monkey.MonkeyToThrowToIfFalse
+			if testTrue, _ := monkey.Test(next.WorryLevel); testTrue {
+				thrownToMonkeyIndex = monkey.MonkeyToThrowToIfTrue
+			}
+
+			// a monkey that already had its turn this round will only get to
+			// the item next round
+			thrownBack := thrownToMonkeyIndex <= next.Monkey
+			next.Monkey = thrownToMonkeyIndex
+			if thrownBack {
+				return next
+			}
+		}
+	}
+	key := func(item itemState) itemKey {
+		return itemKey{item.Monkey, item.WorryLevel}
+	}
+
+	saved := &trajectoryCheckpoint{Rounds: rounds, Trajectories: []cycle.History[itemState]{}}
+	for _, monkey := range monkeys {
+		saved.Monkeys = append(saved.Monkeys, monkey.data())
+	}
+	if _, _, err := checkpoint.Resume(ctx, saved); err != nil {
+		return nil, err
+	}
+
+	item := 0
+	for i, monkey := range monkeys {
+		for _, worryLevel := range monkey.Items {
+			item++
+			// items from a resumed snapshot already have their trajectories
+			if item <= len(saved.Trajectories) {
+				continue
+			}
+
+			start := itemState{Monkey: i, WorryLevel: worryLevel}
+			trajectory, err := cycle.Find(ctx, start, step, key, rounds)
+			if err != nil {
+				if saveErr := checkpoint.Save(ctx, len(saved.Trajectories), saved); saveErr != nil {
+					return nil, saveErr
+				}
+				return nil, err
+			}
+			if operationErr != nil {
+				return nil, operationErr
+			}
+			saved.Trajectories = append(saved.Trajectories, trajectory)
+			if err := checkpoint.Save(ctx, len(saved.Trajectories), saved); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return saved.Trajectories, nil
+}
+
+// trajectoryCheckpoint is how far itemTrajectories has got, counting a step
+// per item: the trajectories of the items done so far, along with the
+// monkeys and rounds they were followed for to check a snapshot's for the
+// same ones.
+type trajectoryCheckpoint struct {
+	Monkeys      []monkeyData
+	Rounds       int
+	Trajectories []cycle.History[itemState]
+}
+
+func (c *trajectoryCheckpoint) Checkpoint() any {
+	return c
+}
+
+func (c *trajectoryCheckpoint) Restore(data json.RawMessage) error {
+	saved := trajectoryCheckpoint{}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+
+	savedMonkeys, _ := json.Marshal(saved.Monkeys)
+	monkeys, _ := json.Marshal(c.Monkeys)
+	if !bytes.Equal(savedMonkeys, monkeys) || saved.Rounds != c.Rounds {
+		return errors.New("snapshot is of different monkeys or rounds")
+	}
+	c.Trajectories = saved.Trajectories
+	return nil
+}
+
+// inspectionCountsAfter totals how many items each monkey inspected in the
+// given number of rounds.
+func inspectionCountsAfter(trajectories []cycle.History[itemState], monkeyCount, rounds int) []int {
+	counts := make([]int, monkeyCount)
+	for _, trajectory := range trajectories {
+		states := trajectory.States
+		// add counts the inspections in the rounds leading up to states
+		// from and to, times over
+		add := func(from, to, times int) {
+			for _, item := range states[from:to] {
+				for m := range counts {
+					if item.Inspected&(1<<m) != 0 {
+						counts[m] += times
+					}
+				}
+			}
+		}
+
+		if rounds < len(states) || !trajectory.Found {
+			add(1, min(rounds+1, len(states)), 1)
+			continue
+		}
+		// the rounds up to the cycle, then the rounds into each state of it
+		// for every time round, then those into the part of a last time round
+		start, length := trajectory.Cycle.Start, trajectory.Cycle.Length
+		cycles, offset := (rounds-start)/length, (rounds-start)%length
+		add(1, start+1, 1)
+		add(start+1, start+length+1, cycles)
+		add(start+1, start+offset+1, 1)
+	}
+	return counts
+}
+
+// generateMonkeys writes monkeys holding size items between them, up to
+// eight monkeys like the real input, with distinct prime divisors. Like the
+// real input, only one monkey squares the worry level, which keeps part two
+// from overflowing. Part one can still overflow before a worry level is
+// divided down again, so the monkeys are rearranged until it doesn't, and
+// after enough tries they stop multiplying at all.
+func generateMonkeys(r *rand.Rand, size int) []string {
+	count := 2 + size/4
+	if count > 8 {
+		count = 8
+	}
+	items := make([][]string, count)
+	for i := 0; i < size; i++ {
+		m := r.Intn(count)
+		items[m] = append(items[m], strconv.Itoa(50+r.Intn(50)))
+	}
+
+	for tries := 0; ; tries++ {
+		lines := arrangeMonkeys(r, items, tries < 100)
+		monkeys, err := parseMonkeys(lines)
+		if err != nil {
+			panic(err)
+		}
+		if _, err := partOne(context.Background(), monkeys); !errors.Is(err, expr.ErrOverflow) {
+			return lines
+		}
+	}
+}
+
+// arrangeMonkeys writes monkeys holding the given items, with random
+// operations, divisors and monkeys to throw to.
+func arrangeMonkeys(r *rand.Rand, items [][]string, multiply bool) []string {
+	primes := []int{2, 3, 5, 7, 11, 13, 17, 19, 23}
+	r.Shuffle(len(primes), func(i, j int) {
+		primes[i], primes[j] = primes[j], primes[i]
+	})
+	squarer := r.Intn(len(items))
+
+	lines := []string{}
+	for m := range items {
+		operation := fmt.Sprintf("old + %d", 1+r.Intn(8))
+		switch {
+		case !multiply:
+		case m == squarer:
+			operation = "old * old"
+		case r.Intn(3) == 0:
+			operation = fmt.Sprintf("old * %d", 2+r.Intn(18))
+		}
+		// anyone but itself
+		ifTrue, ifFalse := r.Intn(len(items)-1), r.Intn(len(items)-1)
+		if ifTrue >= m {
+			ifTrue++
+		}
+		if ifFalse >= m {
+			ifFalse++
+		}
+
+		if m > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines,
+			fmt.Sprintf("Monkey %d:", m),
+			"  Starting items: "+strings.Join(items[m], ", "),
+			"  Operation: new = "+operation,
+			fmt.Sprintf("  Test: divisible by %d", primes[m]),
+			fmt.Sprintf("    If true: throw to monkey %d", ifTrue),
+			fmt.Sprintf("    If false: throw to monkey %d", ifFalse),
+		)
+	}
+	return lines
+}
+
+func parseMonkeys(lines []string) ([]Monkey, error) {
+	return parseMonkeySections(input.Sections(lines))
+}
+
+type Monkey struct {
+	Items                  []int
+	Operation              func(int) (int, error)
+	OperationText          string
+	Test                   func(int) (bool, int)
+	Divisor                int
+	MonkeyToThrowToIfTrue  int
+	MonkeyToThrowToIfFalse int
+}
+
+// monkeyData is a Monkey as plain data, with its closures swapped for what
+// they were made from, so it can be saved in a checkpoint.
+type monkeyData struct {
+	Items                  []int
+	Operation              string
+	Divisor                int
+	MonkeyToThrowToIfTrue  int
+	MonkeyToThrowToIfFalse int
+}
+
+func (m Monkey) data() monkeyData {
+	return monkeyData{
+		Items:                  m.Items,
+		Operation:              m.OperationText,
+		Divisor:                m.Divisor,
+		MonkeyToThrowToIfTrue:  m.MonkeyToThrowToIfTrue,
+		MonkeyToThrowToIfFalse: m.MonkeyToThrowToIfFalse,
+	}
+}
+
+func parseMonkeySections(sections []input.Section) ([]Monkey, error) {
+	monkeys := make([]Monkey, len(sections))
+	if len(sections) < 2 {
+		return nil, input.Expected(0, 0, "at least two monkeys")
+	}
+	if len(sections) > maxMonkeys {
+		return nil, sections[maxMonkeys].Expected(0, 0, fmt.Sprintf("at most %d monkeys", maxMonkeys))
+	}
+	parsedIdentifiers := map[int]bool{}
+
+	for _, section := range sections {
+		if len(section.Lines) != 6 {
+			lineIndex := len(section.Lines)
+			if lineIndex > 6 {
+				lineIndex = 6
+			}
+			return nil, section.Expected(lineIndex, 0, "6 lines describing a monkey")
+		}
+
+		identifierLine := section.Lines[0]
+		identifierLineFields := strings.Fields(identifierLine)
+		if len(identifierLineFields) != 2 || identifierLineFields[0] != "Monkey" {
+			return nil, section.Expected(0, 0, "an identifier line like `Monkey 0:`")
+		}
+		identifier, err := strconv.Atoi(strings.TrimRight(identifierLineFields[1], ":"))
+		if err != nil {
+			return nil, section.ExpectedErr(0, input.FieldColumn(identifierLine, 1), "a monkey number", err)
+		}
+		if identifier < 0 || identifier >= len(monkeys) || parsedIdentifiers[identifier] {
+			return nil, section.Expected(0, input.FieldColumn(identifierLine, 1), fmt.Sprintf("a unique monkey number from 0 to %d", len(monkeys)-1))
+		}
+		parsedIdentifiers[identifier] = true
+
+		monkey := monkeys[identifier]
+
+		startingItemsLine := section.Lines[1]
+		parts := strings.Split(startingItemsLine, ":")
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) != "Starting items" {
+			return nil, section.Expected(1, 0, "a starting items line like `Starting items: 79, 98`")
+		}
+		startingItems := []int{}
+		if strings.TrimSpace(parts[1]) != "" {
+			itemsPartSplitByComma := strings.Split(parts[1], ",")
+			itemColumn := len(parts[0]) + 2
+			for _, itemStr := range itemsPartSplitByComma {
+				item, err := strconv.Atoi(strings.TrimSpace(itemStr))
+				if err != nil {
+					leadingSpace := len(itemStr) - len(strings.TrimLeft(itemStr, " "))
+					return nil, section.ExpectedErr(1, itemColumn+leadingSpace, "a worry level", err)
+				}
+				startingItems = append(startingItems, item)
+				itemColumn += len(itemStr) + 1
+			}
+		}
+		monkey.Items = startingItems
+
+		operationLine := section.Lines[2]
+		operation, operationText, err := parseOperation(section, operationLine)
+		if err != nil {
+			return nil, err
+		}
+		monkey.Operation = operation
+		monkey.OperationText = operationText
+
+		testLine := section.Lines[3]
+		testFields := strings.Fields(testLine)
+		if len(testFields) != 4 || testFields[0] != "Test:" || testFields[1] != "divisible" || testFields[2] != "by" {
+			return nil, section.Expected(3, 0, "a test like `Test: divisible by 23`")
+		}
+		divisibleByValue, err := strconv.Atoi(testFields[3])
+		if err != nil || divisibleByValue <= 0 {
+			return nil, section.ExpectedErr(3, input.FieldColumn(testLine, 3), "a positive divisor", err)
+		}
+		monkey.Divisor = divisibleByValue
+		test := func(newWorry int) (bool, int) {
+			modulo := newWorry % divisibleByValue
+			return modulo == 0, modulo
+		}
+		monkey.Test = test
+
+		trueMonkeyIdentifier, err := parseThrowTarget(section, 4, "If true:", len(monkeys))
+		if err != nil {
+			return nil, err
+		}
+		monkey.MonkeyToThrowToIfTrue = trueMonkeyIdentifier
+
+		falseMonkeyIdentifier, err := parseThrowTarget(section, 5, "If false:", len(monkeys))
+		if err != nil {
+			return nil, err
+		}
+		monkey.MonkeyToThrowToIfFalse = falseMonkeyIdentifier
+
+		monkeys[identifier] = monkey
+	}
+
+	return monkeys, nil
+}
+
+// parseOperation parses the expression on the right of `new =` in terms of
+// `old`, the worry level before the monkey inspects the item, returning it
+// along with its text.
+func parseOperation(section input.Section, operationLine string) (func(int) (int, error), string, error) {
+	parts := strings.SplitN(operationLine, ":", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) != "Operation" {
+		return nil, "", section.Expected(2, 0, "an operation line like `Operation: new = old * 19`")
+	}
+	assignment := strings.SplitN(parts[1], "=", 2)
+	if len(assignment) != 2 || strings.TrimSpace(assignment[0]) != "new" {
+		return nil, "", section.Expected(2, input.FieldColumn(operationLine, 1), "an operation starting with `new =`")
+	}
+
+	// the column the expression starts at, just after the `=`
+	exprColumn := len(parts[0]) + len(assignment[0]) + 3
+	operation, err := expr.Compile(assignment[1])
+	var syntaxErr *expr.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return nil, "", section.ExpectedErr(2, exprColumn+syntaxErr.Pos, syntaxErr.Expected, syntaxErr.Err)
+	}
+	for _, v := range expr.Vars(operation) {
+		if v.Name != "old" {
+			return nil, "", section.Expected(2, exprColumn+v.Pos, "`old` as the only variable")
+		}
+	}
+
+	// one env is reused for every call rather than allocated each time,
+	// since an item is inspected far more often than the input is parsed.
+	// The monkeys are only ever used by the part that parsed them.
+	env := expr.Env{"old": 0}
+	return func(oldWorry int) (int, error) {
+		env["old"] = oldWorry
+		return operation.Eval(env)
+	}, strings.TrimSpace(assignment[1]), nil
+}
+
+func parseThrowTarget(section input.Section, lineIndex int, prefix string, monkeyCount int) (int, error) {
+	line := section.Lines[lineIndex]
+	fields := strings.Fields(line)
+	if !strings.HasPrefix(strings.TrimSpace(line), prefix+" throw to monkey ") || len(fields) != 6 {
+		return 0, section.Expected(lineIndex, 0, fmt.Sprintf("a line like `%s throw to monkey 1`", prefix))
+	}
+	identifier, err := strconv.Atoi(fields[5])
+	if err != nil || identifier < 0 || identifier >= monkeyCount {
+		return 0, section.ExpectedErr(lineIndex, input.FieldColumn(line, 5), fmt.Sprintf("a monkey number from 0 to %d", monkeyCount-1), err)
+	}
+	return identifier, nil
+}
@@ -0,0 +1,38 @@
+package day11
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseMonkeys(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		monkeys, err := parseMonkeys(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkMonkeys(monkeys); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkMonkeys makes sure every monkey can inspect and test an item and
+// throws it to a monkey that exists.
+func checkMonkeys(monkeys []Monkey) error {
+	for i, m := range monkeys {
+		if m.Operation == nil || m.Test == nil || m.Divisor <= 0 {
+			return fmt.Errorf("monkey %d is missing its operation or test", i)
+		}
+		for _, to := range []int{m.MonkeyToThrowToIfTrue, m.MonkeyToThrowToIfFalse} {
+			if to < 0 || to >= len(monkeys) {
+				return fmt.Errorf("monkey %d throws to monkey %d", i, to)
+			}
+		}
+	}
+	return nil
+}
@@ -1,28 +1,21 @@
-package main
+package day2
 
 import (
-	"flag"
-	"fmt"
-	"os"
 	"strings"
 
-	"github.com/Takadimi/aoc/2022/file"
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
 )
 
-var inputFileFlag = flag.String("inputFile", "sample.txt", "Relative file path to use as input.")
-
-func main() {
-	flag.Parse()
-	inputFile := *inputFileFlag
-
-	strategyGuideEntries, err := file.Lines(inputFile)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-
-	fmt.Println("Part One:", partOne(strategyGuideEntries))
-	fmt.Println("Part Two:", partTwo(strategyGuideEntries))
+func init() {
+	runner.Register(runner.Day{
+		Year: 2022,
+		Day:  2,
+		Parts: []runner.PartFunc{
+			runner.Solve(parseStrategyGuidePartOne, partOne),
+			runner.Solve(parseStrategyGuidePartTwo, partTwo),
+		},
+	})
 }
 
 type Choice int
@@ -54,9 +47,7 @@ func determineOutcome(opponentsMove, myMove Choice) Outcome {
 	return outcomes[int(opponentsMove-myMove)]
 }
 
-func partOne(entries []string) int {
-	strategyGuide := parseStrategyGuidePartOne(entries)
-
+func partOne(strategyGuide [][2]Choice) int {
 	cumulativeScore := 0
 	for _, round := range strategyGuide {
 		opponentsMove := round[0]
@@ -74,7 +65,7 @@ func partOne(entries []string) int {
 	return cumulativeScore
 }
 
-func parseStrategyGuidePartOne(entries []string) [][2]Choice {
+func parseStrategyGuidePartOne(entries []string) ([][2]Choice, error) {
 	var choiceByGuide = map[string]Choice{
 		"A": Choice_Rock,
 		"B": Choice_Paper,
@@ -86,24 +77,24 @@ func parseStrategyGuidePartOne(entries []string) [][2]Choice {
 	}
 
 	strategyGuide := [][2]Choice{}
-	for _, line := range entries {
+	for i, line := range entries {
 		fields := strings.Fields(line)
 		if len(fields) != 2 {
-			panic("expected two moves on each strategy guide line")
+			return nil, input.Expected(i, 0, "two moves on each strategy guide line")
 		}
 		firstMove, isValidChoice := choiceByGuide[fields[0]]
-		if !isValidChoice {
-			panic("expected valid choice")
+		if !isValidChoice || fields[0] > "C" {
+			return nil, input.Expected(i, input.FieldColumn(line, 0), "an opponent's move of A, B or C")
 		}
 		secondMove, isValidChoice := choiceByGuide[fields[1]]
-		if !isValidChoice {
-			panic("expected valid choice")
+		if !isValidChoice || fields[1] < "X" {
+			return nil, input.Expected(i, input.FieldColumn(line, 1), "a response of X, Y or Z")
 		}
 
 		strategyGuide = append(strategyGuide, [2]Choice{firstMove, secondMove})
 	}
 
-	return strategyGuide
+	return strategyGuide, nil
 }
 
 type Round struct {
@@ -111,9 +102,7 @@ type Round struct {
 	IntendedOutcome Outcome
 }
 
-func partTwo(entries []string) int {
-	strategyGuide := parseStrategyGuidePartTwo(entries)
-
+func partTwo(strategyGuide []Round) int {
 	matchOpponentMoveForIntendedOutcome := map[int]Choice{
 		(int(Outcome_Draw) + int(Choice_Rock)):     Choice_Rock,
 		(int(Outcome_Draw) + int(Choice_Paper)):    Choice_Paper,
@@ -144,7 +133,7 @@ func partTwo(entries []string) int {
 	return cumulativeScore
 }
 
-func parseStrategyGuidePartTwo(entries []string) []Round {
+func parseStrategyGuidePartTwo(entries []string) ([]Round, error) {
 	var choiceByGuide = map[string]Choice{
 		"A": Choice_Rock,
 		"B": Choice_Paper,
@@ -158,22 +147,22 @@ func parseStrategyGuidePartTwo(entries []string) []Round {
 	}
 
 	strategyGuide := []Round{}
-	for _, line := range entries {
+	for i, line := range entries {
 		fields := strings.Fields(line)
 		if len(fields) != 2 {
-			panic("expected two moves on each strategy guide line")
+			return nil, input.Expected(i, 0, "a move and an outcome on each strategy guide line")
 		}
 		firstMove, isValidChoice := choiceByGuide[fields[0]]
 		if !isValidChoice {
-			panic("expected valid choice")
+			return nil, input.Expected(i, input.FieldColumn(line, 0), "an opponent's move of A, B or C")
 		}
 		intendedOutcome, isValidOutcome := outcomeByGuide[fields[1]]
 		if !isValidOutcome {
-			panic("expected valid outcome")
+			return nil, input.Expected(i, input.FieldColumn(line, 1), "an outcome of X, Y or Z")
 		}
 
 		strategyGuide = append(strategyGuide, Round{firstMove, intendedOutcome})
 	}
 
-	return strategyGuide
+	return strategyGuide, nil
 }
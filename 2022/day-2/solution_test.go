@@ -0,0 +1,64 @@
+package day2
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseStrategyGuidePartOne(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		strategyGuide, err := parseStrategyGuidePartOne(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkStrategyGuidePartOne(strategyGuide); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+func FuzzParseStrategyGuidePartTwo(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		strategyGuide, err := parseStrategyGuidePartTwo(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkStrategyGuidePartTwo(strategyGuide); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkStrategyGuidePartOne makes sure every move is one the rounds can be
+// scored by.
+func checkStrategyGuidePartOne(strategyGuide [][2]Choice) error {
+	for i, round := range strategyGuide {
+		for _, move := range round {
+			if move < Choice_Rock || move > Choice_Scissors {
+				return fmt.Errorf("round %d has move %d", i, move)
+			}
+		}
+	}
+	return nil
+}
+
+// checkStrategyGuidePartTwo makes sure every round has a move and outcome
+// the response can be worked out from.
+func checkStrategyGuidePartTwo(strategyGuide []Round) error {
+	for i, round := range strategyGuide {
+		if round.OpponentsMove < Choice_Rock || round.OpponentsMove > Choice_Scissors {
+			return fmt.Errorf("round %d has move %d", i, round.OpponentsMove)
+		}
+		switch round.IntendedOutcome {
+		case Outcome_Lose, Outcome_Draw, Outcome_Win:
+		default:
+			return fmt.Errorf("round %d has outcome %d", i, round.IntendedOutcome)
+		}
+	}
+	return nil
+}
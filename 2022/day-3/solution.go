@@ -1,55 +1,35 @@
-package main
+package day3
 
 import (
-	"flag"
 	"fmt"
-	"os"
 
-	"github.com/Takadimi/aoc/2022/file"
-	"github.com/Takadimi/aoc/2022/set"
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/seq"
+	"github.com/Takadimi/aoc/set"
 )
 
-var inputFileFlag = flag.String("inputFile", "sample.txt", "Relative file path to use as input.")
-
-func main() {
-	flag.Parse()
-	inputFile := *inputFileFlag
-
-	lines, err := file.Lines(inputFile)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-
-	fmt.Println("Part One:", partOne(lines))
-	fmt.Println("Part Two:", partTwo(lines))
+func init() {
+	runner.Register(runner.Day{
+		Year: 2022,
+		Day:  3,
+		Parts: []runner.PartFunc{
+			runner.Solve(parseRucksacks, partOne),
+			runner.SolveErr(parseRucksacks, partTwo),
+		},
+	})
 }
 
-func partOne(lines []string) int {
-	rucksacks, err := parseRucksacks(lines)
-	if err != nil {
-		panic(err)
-	}
-	prioritySum := 0
-	for _, r := range rucksacks {
-		p := priorityOfItemPresentInBothCompartments(r)
-		prioritySum += p
-	}
-	return prioritySum
+func partOne(rucksacks []Rucksack) int {
+	return seq.Sum(seq.Map(rucksacks, priorityOfItemPresentInBothCompartments))
 }
 
-func partTwo(lines []string) int {
-	rucksacks, err := parseRucksacks(lines)
-	if err != nil {
-		panic(err)
-	}
-	groups := groupRucksacks(rucksacks, 3)
-	prioritySum := 0
-	for _, g := range groups {
-		p := priorityOfItemPresentInAllOfGroup(g)
-		prioritySum += p
+func partTwo(rucksacks []Rucksack) (int, error) {
+	if len(rucksacks)%3 != 0 {
+		return 0, fmt.Errorf("%d rucksacks can't be split into groups of three", len(rucksacks))
 	}
-	return prioritySum
+	groups := seq.Chunk(rucksacks, 3)
+	return seq.Sum(seq.Map(groups, priorityOfItemPresentInAllOfGroup)), nil
 }
 
 type Rucksack struct {
@@ -87,20 +67,12 @@ func priorityOfItemPresentInAllOfGroup(group []Rucksack) int {
 	return 0
 }
 
-func groupRucksacks(rucksacks []Rucksack, groupCount int) [][]Rucksack {
-	groups := make([][]Rucksack, len(rucksacks)/groupCount)
-
-	for i, r := range rucksacks {
-		groupIndex := i / groupCount
-		groups[groupIndex] = append(groups[groupIndex], r)
-	}
-
-	return groups
-}
-
 func parseRucksacks(lines []string) ([]Rucksack, error) {
 	rucksacks := []Rucksack{}
-	for _, line := range lines {
+	for lineIndex, line := range lines {
+		if len(line)%2 != 0 {
+			return nil, input.Expected(lineIndex, 0, "an even number of items to split between two compartments")
+		}
 		rucksack := Rucksack{
 			All: set.NewSet[int](),
 			A:   set.NewSet[int](),
@@ -108,7 +80,10 @@ func parseRucksacks(lines []string) ([]Rucksack, error) {
 		}
 		compartentSize := len(line) / 2
 		for i, char := range line {
-			p := priorityByItemType[char]
+			p, isItemType := priorityByItemType[char]
+			if !isItemType {
+				return nil, input.Expected(lineIndex, i+1, "an item type of a-z or A-Z")
+			}
 			rucksack.All.Set(p)
 			if i < compartentSize {
 				rucksack.A.Set(p)
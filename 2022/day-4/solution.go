@@ -0,0 +1,100 @@
+package day4
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/seq"
+)
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2022,
+		Day:  4,
+		Parts: []runner.PartFunc{
+			runner.Solve(parseAssignmentPairs, partOne),
+			runner.Solve(parseAssignmentPairs, partTwo),
+		},
+	})
+}
+
+func partOne(pairs [][2]Range) int {
+	return seq.Count(pairs, func(p [2]Range) bool {
+		longest, shortest := p[0], p[1]
+		if shortest.length() > longest.length() {
+			longest, shortest = shortest, longest
+		}
+
+		return longest.fullyContains(shortest)
+	})
+}
+
+func partTwo(pairs [][2]Range) int {
+	return seq.Count(pairs, func(p [2]Range) bool {
+		return p[0].intersects(p[1])
+	})
+}
+
+func (r Range) fullyContains(otherRange Range) bool {
+	return r.Start <= otherRange.Start && r.End >= otherRange.End
+}
+
+func (r Range) intersects(otherRange Range) bool {
+	return r.Start <= otherRange.End && r.End >= otherRange.Start
+}
+
+func (r Range) length() int {
+	return (r.End - r.Start) + 1
+}
+
+func parseAssignmentPairs(lines []string) ([][2]Range, error) {
+	assignmentPairs := [][2]Range{}
+	for i, l := range lines {
+		pairParts := strings.Split(l, ",")
+		if len(pairParts) != 2 {
+			return nil, input.Expected(i, 0, "a pair of ranges separated by a comma")
+		}
+
+		firstRange, err := parseRange(i, 1, pairParts[0])
+		if err != nil {
+			return nil, err
+		}
+		secondRange, err := parseRange(i, input.SplitColumn(l, ",", 1), pairParts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		assignmentPairs = append(assignmentPairs, [2]Range{firstRange, secondRange})
+	}
+
+	return assignmentPairs, nil
+}
+
+// parseRange parses the range on line i that starts at column.
+func parseRange(i, column int, rangeString string) (Range, error) {
+	rangeParts := strings.Split(rangeString, "-")
+	if len(rangeParts) != 2 {
+		return Range{}, input.Expected(i, column, "a range like 2-4")
+	}
+
+	rangeStart, err := strconv.Atoi(rangeParts[0])
+	if err != nil {
+		return Range{}, input.ExpectedErr(i, column, "a section number", err)
+	}
+	endColumn := column + input.SplitColumn(rangeString, "-", 1) - 1
+	rangeEnd, err := strconv.Atoi(rangeParts[1])
+	if err != nil {
+		return Range{}, input.ExpectedErr(i, endColumn, "a section number", err)
+	}
+	if rangeEnd < rangeStart {
+		return Range{}, input.Expected(i, endColumn, "a range end no lower than its start")
+	}
+
+	return Range{rangeStart, rangeEnd}, nil
+}
+
+type Range struct {
+	Start, End int
+}
@@ -0,0 +1,34 @@
+package day4
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseAssignmentPairs(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		assignmentPairs, err := parseAssignmentPairs(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkAssignmentPairs(assignmentPairs); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkAssignmentPairs makes sure no range ends before it starts.
+func checkAssignmentPairs(assignmentPairs [][2]Range) error {
+	for i, pair := range assignmentPairs {
+		for _, r := range pair {
+			if r.End < r.Start {
+				return fmt.Errorf("pair %d has range %d-%d", i, r.Start, r.End)
+			}
+		}
+	}
+	return nil
+}
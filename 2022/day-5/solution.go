@@ -0,0 +1,245 @@
+package day5
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
+)
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2022,
+		Day:  5,
+		Parts: []runner.PartFunc{
+			runner.SolveErr(parseCrates, partOne),
+			runner.SolveErr(parseCrates, partTwo),
+		},
+		Generate: generateCrates,
+	})
+}
+
+type Crates struct {
+	Stacks    [][]string
+	Procedure []Instruction
+}
+
+// generateCrates writes a drawing of up to nine stacks, which is as many as
+// single digit stack numbers allow, and a procedure of size steps that
+// never moves more crates than a stack has.
+func generateCrates(r *rand.Rand, size int) []string {
+	stacks := make([][]byte, 2+r.Intn(8))
+	for i := 0; i < len(stacks)+size/10; i++ {
+		s := r.Intn(len(stacks))
+		stacks[s] = append(stacks[s], byte('A'+r.Intn(26)))
+	}
+
+	height := 0
+	for _, s := range stacks {
+		if len(s) > height {
+			height = len(s)
+		}
+	}
+	lines := []string{}
+	for y := height - 1; y >= 0; y-- {
+		row := []string{}
+		for _, s := range stacks {
+			if y < len(s) {
+				row = append(row, "["+string(s[y])+"]")
+			} else {
+				row = append(row, "   ")
+			}
+		}
+		lines = append(lines, strings.Join(row, " "))
+	}
+	ids := []string{}
+	for i := range stacks {
+		ids = append(ids, fmt.Sprintf(" %d ", i+1))
+	}
+	lines = append(lines, strings.Join(ids, " "), "")
+
+	for i := 0; i < size; i++ {
+		from := r.Intn(len(stacks))
+		for len(stacks[from]) == 0 {
+			from = r.Intn(len(stacks))
+		}
+		to := r.Intn(len(stacks) - 1)
+		if to >= from {
+			to++
+		}
+		count := 1 + r.Intn(len(stacks[from]))
+		moved := stacks[from][len(stacks[from])-count:]
+		stacks[to] = append(stacks[to], moved...)
+		stacks[from] = stacks[from][:len(stacks[from])-count]
+		lines = append(lines, fmt.Sprintf("move %d from %d to %d", count, from+1, to+1))
+	}
+	return lines
+}
+
+func parseCrates(lines []string) (Crates, error) {
+	sections := input.Sections(lines)
+	if len(sections) < 2 {
+		return Crates{}, input.Expected(len(lines)-1, 0, "a blank line followed by the rearrangement procedure")
+	}
+	if len(sections) > 2 {
+		return Crates{}, input.Expected(sections[2].Start-1, 0, "only one blank line, between the stacks and the procedure")
+	}
+	startingStacksSection, procedureSection := sections[0], sections[1]
+
+	stacks, err := parseStacks(startingStacksSection)
+	if err != nil {
+		return Crates{}, err
+	}
+	procedure, err := parseProcedure(procedureSection, len(stacks)-1)
+	if err != nil {
+		return Crates{}, err
+	}
+
+	return Crates{Stacks: stacks, Procedure: procedure}, nil
+}
+
+func partOne(crates Crates) (string, error) {
+	stacks, procedure := crates.Stacks, crates.Procedure
+	for _, instruction := range procedure {
+		fromStack := stacks[instruction.From]
+		toStack := stacks[instruction.To]
+
+		if instruction.Count > len(fromStack) {
+			return "", errTooFewCrates(instruction, len(fromStack))
+		}
+
+		for i := 0; i < instruction.Count; i++ {
+			top := fromStack[len(fromStack)-1]
+			toStack = append(toStack, top)
+			fromStack = fromStack[:len(fromStack)-1]
+		}
+
+		stacks[instruction.From] = fromStack
+		stacks[instruction.To] = toStack
+	}
+
+	return topItems(stacks), nil
+}
+
+func partTwo(crates Crates) (string, error) {
+	stacks, procedure := crates.Stacks, crates.Procedure
+	for _, instruction := range procedure {
+		fromStack := stacks[instruction.From]
+		toStack := stacks[instruction.To]
+
+		if instruction.Count > len(fromStack) {
+			return "", errTooFewCrates(instruction, len(fromStack))
+		}
+
+		topNItems := fromStack[len(fromStack)-instruction.Count:]
+		toStack = append(toStack, topNItems...)
+		fromStack = fromStack[:len(fromStack)-instruction.Count]
+
+		stacks[instruction.From] = fromStack
+		stacks[instruction.To] = toStack
+	}
+
+	return topItems(stacks), nil
+}
+
+func errTooFewCrates(instruction Instruction, crateCount int) error {
+	return fmt.Errorf("can't move %d crates from stack %d, it only has %d", instruction.Count, instruction.From, crateCount)
+}
+
+func topItems(stacks [][]string) string {
+	topItems := ""
+	for i := 1; i < len(stacks); i++ {
+		stack := stacks[i]
+		if len(stack) == 0 {
+			continue
+		}
+		topItems += stack[len(stack)-1]
+	}
+	return topItems
+}
+
+func parseStacks(section input.Section) ([][]string, error) {
+	lines := section.Lines
+	if len(lines) == 0 {
+		return nil, section.Expected(0, 0, "a drawing of the starting stacks")
+	}
+
+	stackIDsLineIndex := len(lines) - 1
+	stackIDsLine := lines[stackIDsLineIndex]
+	stackIDIndexes := map[int]int{}
+	for i, id := range stackIDsLine {
+		if id != ' ' {
+			idValue, err := strconv.Atoi(string(id))
+			if err != nil {
+				return nil, section.ExpectedErr(stackIDsLineIndex, i+1, "a stack number", err)
+			}
+			stackIDIndexes[i] = idValue
+		}
+	}
+
+	// stack numbers have to count up from 1 for them to index the stacks
+	seenIDs := map[int]bool{}
+	for i, id := range stackIDsLine {
+		if id == ' ' {
+			continue
+		}
+		idValue := stackIDIndexes[i]
+		if idValue < 1 || idValue > len(stackIDIndexes) || seenIDs[idValue] {
+			return nil, section.Expected(stackIDsLineIndex, i+1, fmt.Sprintf("stack numbers 1 to %d, each used once", len(stackIDIndexes)))
+		}
+		seenIDs[idValue] = true
+	}
+
+	stacks := make([][]string, len(stackIDIndexes)+1)
+
+	for y, l := range lines[:len(lines)-1] {
+		for i, char := range l {
+			if unicode.IsLetter(char) {
+				stackIDIndex, isAboveStack := stackIDIndexes[i]
+				if !isAboveStack {
+					return nil, section.Expected(y, i+1, "a crate lined up with a stack number")
+				}
+				stacks[stackIDIndex] = append([]string{string(char)}, stacks[stackIDIndex]...)
+			}
+		}
+	}
+
+	return stacks, nil
+}
+
+type Instruction struct {
+	Count int
+	From  int
+	To    int
+}
+
+func parseProcedure(section input.Section, stackCount int) ([]Instruction, error) {
+	procedure := []Instruction{}
+	for i, l := range section.Lines {
+		fields := strings.Fields(l)
+		if len(fields) != 6 || fields[0] != "move" || fields[2] != "from" || fields[4] != "to" {
+			return nil, section.Expected(i, 0, "an instruction like `move 1 from 2 to 3`")
+		}
+		countField, fromField, toField := fields[1], fields[3], fields[5]
+		count, err := strconv.Atoi(countField)
+		if err != nil || count < 0 {
+			return nil, section.ExpectedErr(i, input.FieldColumn(l, 1), "a crate count", err)
+		}
+		from, err := strconv.Atoi(fromField)
+		if err != nil || from < 1 || from > stackCount {
+			return nil, section.ExpectedErr(i, input.FieldColumn(l, 3), fmt.Sprintf("a stack number from 1 to %d", stackCount), err)
+		}
+		to, err := strconv.Atoi(toField)
+		if err != nil || to < 1 || to > stackCount {
+			return nil, section.ExpectedErr(i, input.FieldColumn(l, 5), fmt.Sprintf("a stack number from 1 to %d", stackCount), err)
+		}
+
+		procedure = append(procedure, Instruction{Count: count, From: from, To: to})
+	}
+
+	return procedure, nil
+}
@@ -0,0 +1,33 @@
+package day5
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseCrates(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		crates, err := parseCrates(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkCrates(crates); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkCrates makes sure every step of the procedure moves crates between
+// stacks that exist.
+func checkCrates(crates Crates) error {
+	for i, in := range crates.Procedure {
+		if in.Count < 0 || in.From < 1 || in.From >= len(crates.Stacks) || in.To < 1 || in.To >= len(crates.Stacks) {
+			return fmt.Errorf("step %d moves %d from %d to %d with %d stacks", i, in.Count, in.From, in.To, len(crates.Stacks)-1)
+		}
+	}
+	return nil
+}
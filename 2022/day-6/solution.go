@@ -0,0 +1,103 @@
+package day6
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/set"
+)
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2022,
+		Day:  6,
+		Parts: []runner.PartFunc{
+			runner.SolveLines(eachLine(partOne)),
+			runner.SolveLines(eachLine(partTwo)),
+		},
+		Variants: []runner.Variant{
+			{Name: "map", Part: 1, Func: runner.SolveLines(eachLine(partOneByMap))},
+			{Name: "map", Part: 2, Func: runner.SolveLines(eachLine(partTwoByMap))},
+		},
+		Generate: generateDatastreams,
+	})
+}
+
+// eachLine solves every line on its own since the sample holds several
+// datastreams, joining the answers with commas.
+func eachLine(solve func(string) int) func([]string) string {
+	return func(lines []string) string {
+		answers := []string{}
+		for _, l := range lines {
+			answers = append(answers, strconv.Itoa(solve(l)))
+		}
+		return strings.Join(answers, ",")
+	}
+}
+
+func partOne(line string) int {
+	return indexAfterNUniqueCharacters(line, 4)
+}
+
+func partTwo(line string) int {
+	return indexAfterNUniqueCharacters(line, 14)
+}
+
+func partOneByMap(line string) int {
+	return indexAfterNUniqueCharactersByMap(line, 4)
+}
+
+func partTwoByMap(line string) int {
+	return indexAfterNUniqueCharactersByMap(line, 14)
+}
+
+// indexAfterNUniqueCharacters slides a window of n characters along the line,
+// returning how many characters have been read once none in it repeat.
+func indexAfterNUniqueCharacters(line string, n int) int {
+	window := set.NewCounter[byte]()
+	for i := 0; i < len(line); i++ {
+		window.Add(line[i], 1)
+		if i >= n {
+			window.Remove(line[i-n], 1)
+		}
+
+		if i >= n-1 && window.Repeated() == 0 {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// indexAfterNUniqueCharactersByMap is indexAfterNUniqueCharacters building
+// each window's characters afresh, the slow but obvious way.
+func indexAfterNUniqueCharactersByMap(line string, n int) int {
+	for end := n; end <= len(line); end++ {
+		window := map[byte]bool{}
+		for i := end - n; i < end; i++ {
+			window[line[i]] = true
+		}
+		if len(window) == n {
+			return end
+		}
+	}
+
+	return 0
+}
+
+// generateDatastreams writes a few datastreams over alphabets small enough
+// that some never have a marker.
+func generateDatastreams(r *rand.Rand, size int) []string {
+	lines := []string{}
+	for i := r.Intn(3); i >= 0; i-- {
+		alphabet := 2 + r.Intn(18)
+		stream := make([]byte, 1+r.Intn(2*size))
+		for j := range stream {
+			stream[j] = byte('a' + r.Intn(alphabet))
+		}
+		lines = append(lines, string(stream))
+	}
+	return lines
+}
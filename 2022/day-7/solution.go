@@ -1,36 +1,26 @@
-package main
+package day7
 
 import (
-	"flag"
 	"fmt"
-	"os"
+	"math/rand"
 	"path"
 	"strconv"
 	"strings"
 
-	"github.com/Takadimi/aoc/2022/file"
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
 )
 
-var inputFileFlag = flag.String("inputFile", "sample.txt", "Relative file path to use as input.")
-
-func main() {
-	flag.Parse()
-	inputFile := *inputFileFlag
-
-	lines, err := file.Lines(inputFile)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-
-	fileSizesByDir, err := parseFileSizesByDir(lines)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-
-	fmt.Println("Part one:", partOne(fileSizesByDir))
-	fmt.Println("Part two:", partTwo(fileSizesByDir))
+func init() {
+	runner.Register(runner.Day{
+		Year: 2022,
+		Day:  7,
+		Parts: []runner.PartFunc{
+			runner.Solve(parseFileSizesByDir, partOne),
+			runner.Solve(parseFileSizesByDir, partTwo),
+		},
+		Generate: generateTerminalOutput,
+	})
 }
 
 func partOne(fileSizesByDir map[string]int) int {
@@ -60,11 +50,48 @@ func partTwo(fileSizesByDir map[string]int) int {
 	return fileSizeOfDirToDelete
 }
 
+// generateTerminalOutput writes the session of someone browsing a tree of
+// size dirs, listing each dir as they cd into it and going back up with
+// `cd ..` once they've seen everything inside.
+func generateTerminalOutput(r *rand.Rand, size int) []string {
+	// children[d] are the dirs inside dir d, dir 0 being the root
+	children := make([][]int, size)
+	for d := 1; d < size; d++ {
+		parent := r.Intn(d)
+		children[parent] = append(children[parent], d)
+	}
+
+	lines := []string{"$ cd /"}
+	var browse func(d int)
+	browse = func(d int) {
+		lines = append(lines, "$ ls")
+		for _, c := range children[d] {
+			lines = append(lines, fmt.Sprintf("dir d%d", c))
+		}
+		for f := r.Intn(4); f >= 0; f-- {
+			lines = append(lines, fmt.Sprintf("%d f%d.%s", 1+r.Intn(300_000), f, []string{"txt", "dat", "log"}[r.Intn(3)]))
+		}
+		for _, c := range children[d] {
+			lines = append(lines, fmt.Sprintf("$ cd d%d", c))
+			browse(c)
+			lines = append(lines, "$ cd ..")
+		}
+	}
+	if size > 0 {
+		browse(0)
+	}
+	return lines
+}
+
+// maxFileSize keeps dir sizes, which sum the sizes of every file inside,
+// from overflowing for any input that would fit in memory.
+const maxFileSize = 1 << 40
+
 func parseFileSizesByDir(lines []string) (map[string]int, error) {
 	fileSizesByPath := map[string]int{}
 
 	currentPath := ""
-	for _, l := range lines {
+	for i, l := range lines {
 		fields := strings.Fields(l)
 
 		if dir, isChangeDirCommand := matchChangeDirCommand(fields); isChangeDirCommand {
@@ -81,12 +108,18 @@ func parseFileSizesByDir(lines []string) (map[string]int, error) {
 		}
 
 		if fileSize, fileName, isListedFile := matchListedFile(fields); isListedFile {
+			if fileSize < 0 || fileSize > maxFileSize {
+				return nil, input.Expected(i, input.FieldColumn(l, 0), fmt.Sprintf("a file size from 0 to %d", maxFileSize))
+			}
 			fileSizesByPath[path.Clean(currentPath+"/"+fileName)] = fileSize
 			continue
 		}
+
+		return nil, input.Expected(i, 0, "a `$ cd` or `$ ls` command, or a listed dir or file")
 	}
 
-	fileSizesByDir := map[string]int{}
+	// the root is there even with nothing listed in it
+	fileSizesByDir := map[string]int{"/": 0}
 	for filePath, fileSize := range fileSizesByPath {
 		dir, _ := path.Split(filePath)
 
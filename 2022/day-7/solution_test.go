@@ -0,0 +1,41 @@
+package day7
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseFileSizesByDir(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		fileSizesByDir, err := parseFileSizesByDir(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkFileSizesByDir(fileSizesByDir); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkFileSizesByDir makes sure there's a root and no dir is smaller than
+// nothing or than a dir inside it.
+func checkFileSizesByDir(fileSizesByDir map[string]int) error {
+	if _, hasRoot := fileSizesByDir["/"]; !hasRoot {
+		return errors.New("no root dir")
+	}
+	for dir, fileSize := range fileSizesByDir {
+		if fileSize < 0 {
+			return fmt.Errorf("%s holds %d", dir, fileSize)
+		}
+		if parent := path.Dir(dir); fileSizesByDir[parent] < fileSize {
+			return fmt.Errorf("%s holds %d but is inside %s holding %d", dir, fileSize, parent, fileSizesByDir[parent])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package day8
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/parallel"
+	"github.com/Takadimi/aoc/runner"
+)
+
+func init() {
+	runner.Register(runner.Day{
+		Year: 2022,
+		Day:  8,
+		Parts: []runner.PartFunc{
+			runner.Solve(parseTreeMap, sumOfVisibleTrees),
+			runner.SolveCtx(parseTreeMap, highestScenicScore),
+		},
+		Variants: []runner.Variant{
+			{Name: "sweep", Part: 1, Func: runner.Solve(parseTreeMap, sumOfVisibleTreesBySweep)},
+			{Name: "serial", Part: 2, Func: runner.Solve(parseTreeMap, highestScenicScoreSerial)},
+			{Name: "stack", Part: 2, Func: runner.Solve(parseTreeMap, highestScenicScoreByStack)},
+		},
+		Generate: generateTreeMap,
+	})
+}
+
+func sumOfVisibleTrees(treeMap [][]int) int {
+	// a single row or column is all edge
+	if len(treeMap) == 1 || len(treeMap[0]) == 1 {
+		return len(treeMap) * len(treeMap[0])
+	}
+
+	// initialize sum with number of edge trees since they're always visible
+	sum := ((len(treeMap) - 1) * 2) + ((len(treeMap[0]) - 1) * 2)
+
+	// evaluate interior trees
+	for y := 1; y < len(treeMap)-1; y++ {
+		for x := 1; x < len(treeMap[y])-1; x++ {
+			tree := treeMap[y][x]
+
+			checkVisibility := func(wTree int) bool {
+				return wTree < tree
+			}
+			visibleNorth := walkTreeMapNorth(treeMap, x, y, checkVisibility)
+			visibleSouth := walkTreeMapSouth(treeMap, x, y, checkVisibility)
+			visibleEast := walkTreeMapEast(treeMap, x, y, checkVisibility)
+			visibleWest := walkTreeMapWest(treeMap, x, y, checkVisibility)
+
+			if visibleNorth || visibleSouth || visibleEast || visibleWest {
+				sum++
+			}
+		}
+	}
+
+	return sum
+}
+
+// highestScenicScore scores every tree at once, since no tree's score
+// depends on another's.
+func highestScenicScore(ctx context.Context, treeMap [][]int) (int, error) {
+	width := len(treeMap[0])
+	return parallel.MapReduce(ctx, len(treeMap)*width, func(i int) int {
+		return scenicScore(treeMap, i%width, i/width)
+	}, func(highest, score int) int {
+		if score > highest {
+			return score
+		}
+		return highest
+	}, 0)
+}
+
+func highestScenicScoreSerial(treeMap [][]int) int {
+	highestScenicScore := 0
+
+	for y := 0; y < len(treeMap); y++ {
+		for x := 0; x < len(treeMap[y]); x++ {
+			totalScore := scenicScore(treeMap, x, y)
+			if totalScore > highestScenicScore {
+				highestScenicScore = totalScore
+			}
+		}
+	}
+
+	return highestScenicScore
+}
+
+func scenicScore(treeMap [][]int, x, y int) int {
+	tree := treeMap[y][x]
+
+	northScore := 0
+	southScore := 0
+	eastScore := 0
+	westScore := 0
+
+	walkTreeMapNorth(treeMap, x, y, func(wTree int) bool {
+		northScore++
+		return wTree < tree
+	})
+	walkTreeMapSouth(treeMap, x, y, func(wTree int) bool {
+		southScore++
+		return wTree < tree
+	})
+	walkTreeMapEast(treeMap, x, y, func(wTree int) bool {
+		eastScore++
+		return wTree < tree
+	})
+	walkTreeMapWest(treeMap, x, y, func(wTree int) bool {
+		westScore++
+		return wTree < tree
+	})
+
+	return northScore * southScore * eastScore * westScore
+}
+
+// sightLines lists every row and column of the map as the positions along
+// it, once in each direction, so a tree shows up in four of them: looking
+// in from the west, east, north and south.
+func sightLines(treeMap [][]int) [][][2]int {
+	height, width := len(treeMap), len(treeMap[0])
+	lines := [][][2]int{}
+	for y := 0; y < height; y++ {
+		fromWest, fromEast := [][2]int{}, [][2]int{}
+		for x := 0; x < width; x++ {
+			fromWest = append(fromWest, [2]int{x, y})
+			fromEast = append(fromEast, [2]int{width - 1 - x, y})
+		}
+		lines = append(lines, fromWest, fromEast)
+	}
+	for x := 0; x < width; x++ {
+		fromNorth, fromSouth := [][2]int{}, [][2]int{}
+		for y := 0; y < height; y++ {
+			fromNorth = append(fromNorth, [2]int{x, y})
+			fromSouth = append(fromSouth, [2]int{x, height - 1 - y})
+		}
+		lines = append(lines, fromNorth, fromSouth)
+	}
+	return lines
+}
+
+// sumOfVisibleTreesBySweep looks along every sight line once, keeping track
+// of the tallest tree so far; a tree is visible if it's taller still.
+func sumOfVisibleTreesBySweep(treeMap [][]int) int {
+	visible := map[[2]int]bool{}
+	for _, line := range sightLines(treeMap) {
+		tallest := -1
+		for _, p := range line {
+			if tree := treeMap[p[1]][p[0]]; tree > tallest {
+				visible[p] = true
+				tallest = tree
+			}
+		}
+	}
+	return len(visible)
+}
+
+// highestScenicScoreByStack works out how far back along each sight line
+// every tree can see with a stack of the trees it could still be blocked
+// by, shortest on top. Trees shorter than the current one can't block
+// anything after it, so each tree is only pushed and popped once per line.
+func highestScenicScoreByStack(treeMap [][]int) int {
+	scores := map[[2]int]int{}
+	for _, line := range sightLines(treeMap) {
+		stack := []int{}
+		for i, p := range line {
+			tree := treeMap[p[1]][p[0]]
+			for len(stack) > 0 {
+				top := line[stack[len(stack)-1]]
+				if treeMap[top[1]][top[0]] >= tree {
+					break
+				}
+				stack = stack[:len(stack)-1]
+			}
+
+			// it sees back to the blocking tree, or to the edge
+			distance := i
+			if len(stack) > 0 {
+				distance = i - stack[len(stack)-1]
+			}
+			if score, isScored := scores[p]; isScored {
+				scores[p] = score * distance
+			} else {
+				scores[p] = distance
+			}
+			stack = append(stack, i)
+		}
+	}
+
+	highest := 0
+	for _, score := range scores {
+		if score > highest {
+			highest = score
+		}
+	}
+	return highest
+}
+
+type treeWalkFunc func(int) bool
+
+func walkTreeMapNorth(treeMap [][]int, startingX, startingY int, walkFunc treeWalkFunc) bool {
+	for cy := startingY - 1; cy >= 0; cy-- {
+		if keepWalking := walkFunc(treeMap[cy][startingX]); !keepWalking {
+			return false
+		}
+	}
+	return true
+}
+
+func walkTreeMapSouth(treeMap [][]int, startingX, startingY int, walkFunc treeWalkFunc) bool {
+	for cy := startingY + 1; cy < len(treeMap); cy++ {
+		if keepWalking := walkFunc(treeMap[cy][startingX]); !keepWalking {
+			return false
+		}
+	}
+	return true
+}
+
+func walkTreeMapEast(treeMap [][]int, startingX, startingY int, walkFunc treeWalkFunc) bool {
+	for cx := startingX + 1; cx < len(treeMap[startingY]); cx++ {
+		if keepWalking := walkFunc(treeMap[startingY][cx]); !keepWalking {
+			return false
+		}
+	}
+	return true
+}
+
+func walkTreeMapWest(treeMap [][]int, startingX, startingY int, walkFunc treeWalkFunc) bool {
+	for cx := startingX - 1; cx >= 0; cx-- {
+		if keepWalking := walkFunc(treeMap[startingY][cx]); !keepWalking {
+			return false
+		}
+	}
+	return true
+}
+
+func generateTreeMap(r *rand.Rand, size int) []string {
+	width, height := 1+r.Intn(size), 1+r.Intn(size)
+	lines := []string{}
+	for y := 0; y < height; y++ {
+		row := make([]byte, width)
+		for x := range row {
+			row[x] = byte('0' + r.Intn(10))
+		}
+		lines = append(lines, string(row))
+	}
+	return lines
+}
+
+func parseTreeMap(lines []string) ([][]int, error) {
+	treeMap := [][]int{}
+	if len(lines) == 0 || lines[0] == "" {
+		return nil, input.Expected(0, 0, "a row of tree heights")
+	}
+	for y := 0; y < len(lines); y++ {
+		if len(lines[y]) != len(lines[0]) {
+			return nil, input.Expected(y, 0, fmt.Sprintf("%d tree heights like the first row", len(lines[0])))
+		}
+		treeMap = append(treeMap, []int{})
+		for x := 0; x < len(lines[y]); x++ {
+			v, err := strconv.Atoi(string(lines[y][x]))
+			if err != nil {
+				return nil, input.ExpectedErr(y, x+1, "a tree height of 0-9", err)
+			}
+			treeMap[y] = append(treeMap[y], v)
+		}
+	}
+
+	return treeMap, nil
+}
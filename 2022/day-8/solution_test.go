@@ -0,0 +1,97 @@
+package day8
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+// realTreeMap parses the real input for a benchmark, skipping it if the
+// input isn't there.
+func realTreeMap(b *testing.B) [][]int {
+	b.Helper()
+	lines, err := input.Lines("input.txt")
+	if err != nil {
+		b.Skip(err)
+	}
+	treeMap, err := parseTreeMap(lines)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	return treeMap
+}
+
+func BenchmarkPartOne(b *testing.B) {
+	treeMap := realTreeMap(b)
+	for i := 0; i < b.N; i++ {
+		sumOfVisibleTrees(treeMap)
+	}
+}
+
+func BenchmarkPartOneBySweep(b *testing.B) {
+	treeMap := realTreeMap(b)
+	for i := 0; i < b.N; i++ {
+		sumOfVisibleTreesBySweep(treeMap)
+	}
+}
+
+func BenchmarkPartTwo(b *testing.B) {
+	treeMap := realTreeMap(b)
+	for i := 0; i < b.N; i++ {
+		if _, err := highestScenicScore(context.Background(), treeMap); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPartTwoSerial(b *testing.B) {
+	treeMap := realTreeMap(b)
+	for i := 0; i < b.N; i++ {
+		highestScenicScoreSerial(treeMap)
+	}
+}
+
+func BenchmarkPartTwoByStack(b *testing.B) {
+	treeMap := realTreeMap(b)
+	for i := 0; i < b.N; i++ {
+		highestScenicScoreByStack(treeMap)
+	}
+}
+
+func FuzzParseTreeMap(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		treeMap, err := parseTreeMap(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkTreeMap(treeMap); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkTreeMap makes sure the map is a rectangle of heights 0-9, which the
+// sight lines are walked across.
+func checkTreeMap(treeMap [][]int) error {
+	if len(treeMap) == 0 || len(treeMap[0]) == 0 {
+		return errors.New("no trees")
+	}
+	for y, row := range treeMap {
+		if len(row) != len(treeMap[0]) {
+			return fmt.Errorf("row %d has %d trees, not %d", y, len(row), len(treeMap[0]))
+		}
+		for x, h := range row {
+			if h < 0 || h > 9 {
+				return fmt.Errorf("tree at %d,%d is %d high", x, y, h)
+			}
+		}
+	}
+	return nil
+}
@@ -1,36 +1,33 @@
-package main
+package day9
 
 import (
-	"errors"
-	"flag"
 	"fmt"
-	"os"
+	"math/rand"
 	"strconv"
 	"strings"
 
-	"github.com/Takadimi/aoc/2022/file"
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
 )
 
-var inputFileFlag = flag.String("inputFile", "sample.txt", "Relative file path to use as input.")
-
-func main() {
-	flag.Parse()
-	inputFile := *inputFileFlag
-
-	lines, err := file.Lines(inputFile)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
+func init() {
+	runner.Register(runner.Day{
+		Year: 2022,
+		Day:  9,
+		Parts: []runner.PartFunc{
+			runner.Solve(parseHeadMotionSeries, partOne),
+			runner.Solve(parseHeadMotionSeries, partTwo),
+		},
+		Generate: generateHeadMotionSeries,
+	})
+}
 
-	headMotionSeries, err := parseHeadMotionSeries(lines)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
+func partOne(headMotionSeries []Motion) int {
+	return len(simulate(headMotionSeries, 1)[0].VisitedPositions)
+}
 
-	fmt.Println("Part one:", len(simulate(headMotionSeries, 1)[0].VisitedPositions))
-	fmt.Println("Part two:", len(simulate(headMotionSeries, 10)[8].VisitedPositions))
+func partTwo(headMotionSeries []Motion) int {
+	return len(simulate(headMotionSeries, 10)[8].VisitedPositions)
 }
 
 type Tail struct {
@@ -127,13 +124,22 @@ type Motion struct {
 	Steps int
 }
 
+// generateHeadMotionSeries writes size motions of up to 20 steps each.
+func generateHeadMotionSeries(r *rand.Rand, size int) []string {
+	lines := []string{}
+	for i := 0; i < size; i++ {
+		lines = append(lines, fmt.Sprintf("%s %d", []string{"U", "D", "L", "R"}[r.Intn(4)], 1+r.Intn(20)))
+	}
+	return lines
+}
+
 func parseHeadMotionSeries(lines []string) ([]Motion, error) {
 	motionSeries := []Motion{}
 
-	for _, l := range lines {
+	for i, l := range lines {
 		fields := strings.Fields(l)
 		if len(fields) != 2 {
-			return nil, errors.New("expected 2 fields per line for motion series")
+			return nil, input.Expected(i, 0, "a direction and a step count")
 		}
 		var dir Direction
 		switch fields[0] {
@@ -146,12 +152,12 @@ func parseHeadMotionSeries(lines []string) ([]Motion, error) {
 		case "R":
 			dir = Direction_Right
 		default:
-			return nil, errors.New("no matching direction identifier")
+			return nil, input.Expected(i, input.FieldColumn(l, 0), "a direction of U, D, L or R")
 		}
 
 		steps, err := strconv.Atoi(fields[1])
-		if err != nil {
-			return nil, err
+		if err != nil || steps < 0 {
+			return nil, input.ExpectedErr(i, input.FieldColumn(l, 1), "a step count", err)
 		}
 
 		motionSeries = append(motionSeries, Motion{Dir: dir, Steps: steps})
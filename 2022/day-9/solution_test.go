@@ -0,0 +1,32 @@
+package day9
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/input/inputtest"
+)
+
+func FuzzParseHeadMotionSeries(f *testing.F) {
+	inputtest.Seed(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		motionSeries, err := parseHeadMotionSeries(input.SplitLines(text))
+		if err != nil {
+			return
+		}
+		if err := checkHeadMotionSeries(motionSeries); err != nil {
+			t.Fatalf("parsed without an error but %v", err)
+		}
+	})
+}
+
+// checkHeadMotionSeries makes sure every motion goes somewhere.
+func checkHeadMotionSeries(motionSeries []Motion) error {
+	for i, m := range motionSeries {
+		if m.Dir < Direction_Up || m.Dir > Direction_Right || m.Steps < 0 {
+			return fmt.Errorf("motion %d is %d steps in direction %d", i, m.Steps, m.Dir)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Package checkpoint lets long running simulations save their state as they
+// go and pick up again from a saved snapshot, so a run can be stopped and
+// resumed or two runs compared at the same step.
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// State is a simulation's state that can be saved to a snapshot. Anything
+// that can't be saved as is, like a closure, has to be saved as the data it
+// was made from.
+type State interface {
+	// Checkpoint is the state as data to encode into a snapshot as JSON.
+	Checkpoint() any
+	// Restore replaces the state with one decoded from a snapshot.
+	Restore(data json.RawMessage) error
+}
+
+// Snapshot is a simulation's state saved after a number of steps, however
+// the simulation counts them.
+type Snapshot struct {
+	Year  int             `json:"year"`
+	Day   int             `json:"day"`
+	Part  int             `json:"part"`
+	Step  int             `json:"step"`
+	State json.RawMessage `json:"state"`
+}
+
+// Load reads a snapshot saved by a Session.
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Snapshot{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Session is where one part of a day saves snapshots and what it resumes
+// from.
+type Session struct {
+	Year, Day, Part int
+	// Dir is where snapshots are saved, named by day, part and step. Nothing
+	// is saved if it's empty.
+	Dir string
+	// Every is how many steps go between snapshots. Snapshots are also
+	// saved when the part is cancelled or times out.
+	Every int
+	// Resume is the snapshot to start from, if any.
+	Resume *Snapshot
+}
+
+type sessionKey struct{}
+
+// WithSession gives the parts run with ctx a session to checkpoint to.
+func WithSession(ctx context.Context, s *Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, s)
+}
+
+func sessionFrom(ctx context.Context) *Session {
+	s, _ := ctx.Value(sessionKey{}).(*Session)
+	return s
+}
+
+// Resume restores state from the snapshot the part is resuming from,
+// returning the step it was saved at. Without one it leaves state alone and
+// returns false.
+func Resume(ctx context.Context, state State) (int, bool, error) {
+	s := sessionFrom(ctx)
+	if s == nil || s.Resume == nil {
+		return 0, false, nil
+	}
+	if s.Resume.Year != s.Year || s.Resume.Day != s.Day || s.Resume.Part != s.Part {
+		return 0, false, fmt.Errorf("snapshot is of %d day %d part %d, not %d day %d part %d", s.Resume.Year, s.Resume.Day, s.Resume.Part, s.Year, s.Day, s.Part)
+	}
+	if err := state.Restore(s.Resume.State); err != nil {
+		return 0, false, fmt.Errorf("resuming from step %d: %w", s.Resume.Step, err)
+	}
+	return s.Resume.Step, true, nil
+}
+
+// Save snapshots state after a step if it's time to: every so many steps,
+// or once the part has been cancelled so it can carry on later. Simulations
+// call it after every step, and again on their way out if cancelled.
+func Save(ctx context.Context, step int, state State) error {
+	s := sessionFrom(ctx)
+	if s == nil || s.Dir == "" {
+		return nil
+	}
+	if ctx.Err() == nil && (s.Every < 1 || step%s.Every != 0) {
+		return nil
+	}
+
+	encoded, err := encode(state.Checkpoint(), "")
+	if err != nil {
+		return err
+	}
+	data, err := encode(Snapshot{Year: s.Year, Day: s.Day, Part: s.Part, Step: step, State: encoded}, "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.Path(step), data, 0o644)
+}
+
+// encode is json.Marshal leaving characters like `>` alone, since they're
+// all over puzzle maps.
+func encode(v any, indent string) ([]byte, error) {
+	buf := bytes.Buffer{}
+	e := json.NewEncoder(&buf)
+	e.SetEscapeHTML(false)
+	e.SetIndent("", indent)
+	if err := e.Encode(v); err != nil {
+		return nil, fmt.Errorf("checkpoint: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Path is where the snapshot after a step is saved.
+func (s *Session) Path(step int) string {
+	return filepath.Join(s.Dir, fmt.Sprintf("%d-%d-%d-step-%d.json", s.Year, s.Day, s.Part, step))
+}
@@ -0,0 +1,101 @@
+package checkpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Diff lists where two snapshots' states differ, one line per differing
+// value, each led by the path to it like `monkeys[2].items[0]`.
+func Diff(a, b *Snapshot) ([]string, error) {
+	diffs := []string{}
+	if a.Year != b.Year || a.Day != b.Day || a.Part != b.Part {
+		diffs = append(diffs, fmt.Sprintf("part: %d day %d part %d != %d day %d part %d", a.Year, a.Day, a.Part, b.Year, b.Day, b.Part))
+	}
+	if a.Step != b.Step {
+		diffs = append(diffs, fmt.Sprintf("step: %d != %d", a.Step, b.Step))
+	}
+
+	stateA, err := decode(a.State)
+	if err != nil {
+		return nil, err
+	}
+	stateB, err := decode(b.State)
+	if err != nil {
+		return nil, err
+	}
+	return diff(diffs, "state", stateA, stateB), nil
+}
+
+// decode keeps numbers as written so big ones aren't rounded off as floats.
+func decode(data json.RawMessage) (any, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("checkpoint: %w", err)
+	}
+	return v, nil
+}
+
+func diff(diffs []string, path string, a, b any) []string {
+	switch a := a.(type) {
+	case map[string]any:
+		b, isObject := b.(map[string]any)
+		if !isObject {
+			break
+		}
+		keys := []string{}
+		for k := range a {
+			keys = append(keys, k)
+		}
+		for k := range b {
+			if _, inA := a[k]; !inA {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			valueA, inA := a[k]
+			valueB, inB := b[k]
+			switch {
+			case !inA:
+				diffs = append(diffs, fmt.Sprintf("%s.%s: missing != %s", path, k, format(valueB)))
+			case !inB:
+				diffs = append(diffs, fmt.Sprintf("%s.%s: %s != missing", path, k, format(valueA)))
+			default:
+				diffs = diff(diffs, path+"."+k, valueA, valueB)
+			}
+		}
+		return diffs
+
+	case []any:
+		b, isArray := b.([]any)
+		if !isArray {
+			break
+		}
+		if len(a) != len(b) {
+			diffs = append(diffs, fmt.Sprintf("%s: %d elements != %d elements", path, len(a), len(b)))
+		}
+		for i := 0; i < len(a) && i < len(b); i++ {
+			diffs = diff(diffs, path+"["+strconv.Itoa(i)+"]", a[i], b[i])
+		}
+		return diffs
+	}
+
+	if format(a) != format(b) {
+		diffs = append(diffs, fmt.Sprintf("%s: %s != %s", path, format(a), format(b)))
+	}
+	return diffs
+}
+
+func format(v any) string {
+	data, err := encode(v, "")
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(bytes.TrimSuffix(data, []byte("\n")))
+}
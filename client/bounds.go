@@ -0,0 +1,135 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrOutOfBounds   = errors.New("answer is outside the known bounds")
+	ErrAlreadySolved = errors.New("part is already solved")
+)
+
+// Bounds is everything the submission log says about one part's answer.
+// Lower and Upper are exclusive: the answer is known to be above Lower and
+// below Upper when HasLower and HasUpper are set.
+type Bounds struct {
+	Attempts int
+	Lower    int64
+	HasLower bool
+	Upper    int64
+	HasUpper bool
+	Rejected []string
+	Solved   string
+}
+
+// NewBounds folds judged submissions into the tightest known bounds.
+func NewBounds(submissions []Submission) Bounds {
+	b := Bounds{}
+	for _, s := range submissions {
+		b.Record(s.Answer, s.Verdict)
+	}
+	return b
+}
+
+// Record narrows the bounds with the feedback one answer got.
+func (b *Bounds) Record(answer string, verdict Verdict) {
+	if verdict == VerdictRateLimited || verdict == VerdictUnknown {
+		return
+	}
+	b.Attempts++
+
+	if verdict == VerdictCorrect {
+		b.Solved = answer
+		return
+	}
+	if !verdict.IsRejection() {
+		return
+	}
+
+	if !b.isRejected(answer) {
+		b.Rejected = append(b.Rejected, answer)
+	}
+
+	n, isNumber := parseAnswerNumber(answer)
+	if !isNumber {
+		return
+	}
+	switch verdict {
+	case VerdictTooLow:
+		if !b.HasLower || n > b.Lower {
+			b.Lower, b.HasLower = n, true
+		}
+	case VerdictTooHigh:
+		if !b.HasUpper || n < b.Upper {
+			b.Upper, b.HasUpper = n, true
+		}
+	}
+}
+
+// Check returns why an answer shouldn't be submitted, or nil if it might be right.
+func (b Bounds) Check(answer string) error {
+	if b.Solved != "" {
+		if b.Solved == answer {
+			return nil
+		}
+		return fmt.Errorf("%w with %s", ErrAlreadySolved, b.Solved)
+	}
+
+	if b.isRejected(answer) {
+		return fmt.Errorf("%w: %s", ErrAlreadyRejected, answer)
+	}
+
+	n, isNumber := parseAnswerNumber(answer)
+	if !isNumber {
+		return nil
+	}
+	if b.HasLower && n <= b.Lower {
+		return fmt.Errorf("%w: %d is not above %d, which was too low", ErrOutOfBounds, n, b.Lower)
+	}
+	if b.HasUpper && n >= b.Upper {
+		return fmt.Errorf("%w: %d is not below %d, which was too high", ErrOutOfBounds, n, b.Upper)
+	}
+
+	return nil
+}
+
+// Range renders the bounds as an interval, e.g. "(120, 4096)" or "(120, ?)".
+func (b Bounds) Range() string {
+	if !b.HasLower && !b.HasUpper {
+		return "-"
+	}
+	lower, upper := "?", "?"
+	if b.HasLower {
+		lower = strconv.FormatInt(b.Lower, 10)
+	}
+	if b.HasUpper {
+		upper = strconv.FormatInt(b.Upper, 10)
+	}
+	return "(" + lower + ", " + upper + ")"
+}
+
+func (b Bounds) isRejected(answer string) bool {
+	for _, rejected := range b.Rejected {
+		if rejected == answer {
+			return true
+		}
+	}
+	return false
+}
+
+func parseAnswerNumber(answer string) (int64, bool) {
+	n, err := strconv.ParseInt(strings.TrimSpace(answer), 10, 64)
+	return n, err == nil
+}
+
+// Bounds reads the bounds for one part out of the log.
+func (l *SubmissionLog) Bounds(year, day, part int) (Bounds, error) {
+	submissions, err := l.For(year, day, part)
+	if err != nil {
+		return Bounds{}, err
+	}
+	return NewBounds(submissions), nil
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBaseURL = "https://adventofcode.com"
+	userAgent      = "github.com/Takadimi/aoc"
+)
+
+// HTTPClient is the slice of *http.Client the client needs, so tests and
+// local stand-in servers can be swapped in.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+var (
+	ErrNoSession       = errors.New("no session cookie configured (set " + SessionEnv + " or write it to the session file)")
+	ErrAlreadyRejected = errors.New("answer was already rejected")
+)
+
+type Client struct {
+	BaseURL string
+	Session string
+	HTTP    HTTPClient
+	// Dir holds the input cache and the submission log.
+	Dir string
+	// Force submits answers that fall outside the known bounds anyway.
+	Force bool
+}
+
+// New builds a client from the environment and config directory.
+func New() (*Client, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := LoadSession(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := os.Getenv(BaseURLEnv)
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &Client{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Session: session,
+		HTTP:    &http.Client{Timeout: 30 * time.Second},
+		Dir:     dir,
+	}, nil
+}
+
+// InputPath is where a day's input is cached, whether or not it's been fetched yet.
+func (c *Client) InputPath(year, day int) string {
+	return filepath.Join(c.Dir, "inputs", strconv.Itoa(year), fmt.Sprintf("day-%d.txt", day))
+}
+
+// Input returns the path to a day's cached input, downloading it first if needed.
+func (c *Client) Input(ctx context.Context, year, day int) (string, error) {
+	inputPath := c.InputPath(year, day)
+	if _, err := os.Stat(inputPath); err == nil {
+		return inputPath, nil
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching %d day %d input: %s: %s", year, day, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(inputPath), 0o700); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(inputPath, body, 0o600); err != nil {
+		return "", err
+	}
+
+	return inputPath, nil
+}
+
+// Submit sends an answer unless the submission log already says how it
+// will be judged. Previously rejected answers return ErrAlreadyRejected and
+// are never resent. Answers outside the bounds learned from "too high" and
+// "too low" feedback return ErrOutOfBounds unless Force is set.
+func (c *Client) Submit(ctx context.Context, year, day, part int, answer string) (Result, error) {
+	log := c.Log()
+
+	bounds, err := log.Bounds(year, day, part)
+	if err != nil {
+		return Result{}, err
+	}
+	if bounds.Solved != "" && bounds.Solved == answer {
+		previous, err := log.Find(year, day, part, answer)
+		if err != nil {
+			return Result{}, err
+		}
+		return previous.Result(), nil
+	}
+	if err := bounds.Check(answer); err != nil && !(c.Force && errors.Is(err, ErrOutOfBounds)) {
+		return Result{}, err
+	}
+
+	form := url.Values{}
+	form.Set("level", strconv.Itoa(part))
+	form.Set("answer", answer)
+
+	resp, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Result{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Result{}, fmt.Errorf("submitting %d day %d part %d: %s", year, day, part, resp.Status)
+	}
+
+	result := ParseResponse(string(body))
+
+	if err := log.Append(Submission{
+		Year:    year,
+		Day:     day,
+		Part:    part,
+		Answer:  answer,
+		Verdict: result.Verdict,
+		Time:    time.Now(),
+	}); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Log is the client's local record of submissions.
+func (c *Client) Log() *SubmissionLog {
+	return NewSubmissionLog(c.Dir)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	req.Header.Set("User-Agent", userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	return c.HTTP.Do(req)
+}
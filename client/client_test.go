@@ -0,0 +1,228 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pages are the articles the site answers a submission with, trimmed down.
+var pages = map[Verdict]string{
+	VerdictCorrect:     `<p>That's the right answer!  You are <span class="day-success">one gold star</span> closer to collecting enough star fruit. <a href="/2022/day/1#part2">[Continue to Part Two]</a></p>`,
+	VerdictWrong:       `<p>That's not the right answer.  If you're stuck, make sure you're using the full input data. <a href="/2022/day/1">[Return to Day 1]</a></p>`,
+	VerdictTooHigh:     `<p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data. <a href="/2022/day/1">[Return to Day 1]</a></p>`,
+	VerdictTooLow:      `<p>That's not the right answer; your answer is too low.  If you're stuck, make sure you're using the full input data. <a href="/2022/day/1">[Return to Day 1]</a></p>`,
+	VerdictRateLimited: `<p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 37s left to wait. <a href="/2022/day/1">[Return to Day 1]</a></p>`,
+	VerdictWrongLevel:  `<p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2022/day/1">[Return to Day 1]</a></p>`,
+}
+
+func page(article string) string {
+	return "<!DOCTYPE html>\n<html><head><title>Day 1 - Advent of Code 2022</title></head><body>\n<main>\n<article>" + article + "</article>\n</main>\n</body></html>"
+}
+
+func TestParseResponse(t *testing.T) {
+	for verdict, article := range pages {
+		result := ParseResponse(page(article))
+		if result.Verdict != verdict {
+			t.Errorf("%s page parsed as %s: %q", verdict, result.Verdict, result.Message)
+		}
+	}
+
+	tests := []struct {
+		article string
+		wait    time.Duration
+	}{
+		{pages[VerdictRateLimited], 4*time.Minute + 37*time.Second},
+		{`<p>You gave an answer too recently.  You have 12s left to wait.</p>`, 12 * time.Second},
+		{`<p>You gave an answer too recently.</p>`, 0},
+	}
+	for _, test := range tests {
+		result := ParseResponse(page(test.article))
+		if result.Verdict != VerdictRateLimited || result.Wait != test.wait {
+			t.Errorf("%q parsed as %s waiting %s, want rate limited waiting %s", test.article, result.Verdict, result.Wait, test.wait)
+		}
+	}
+
+	if result := ParseResponse(page(`<p>Something &amp; else.</p>`)); result.Verdict != VerdictUnknown || result.Message != "Something & else." {
+		t.Errorf("unrecognised page parsed as %s: %q", result.Verdict, result.Message)
+	}
+}
+
+// standIn is a stand-in for the site that judges answers to 2022 day 1
+// part 1, whose answer is 150, and counts what's posted to it.
+type standIn struct {
+	mu          sync.Mutex
+	posted      []string
+	rateLimited bool
+}
+
+func (s *standIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+		http.Error(w, "no session", http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodPost || r.URL.Path != "/2022/day/1/answer" || r.FormValue("level") != "1" {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	answer := r.FormValue("answer")
+	s.posted = append(s.posted, answer)
+
+	verdict := VerdictCorrect
+	n, err := strconv.Atoi(answer)
+	switch {
+	case s.rateLimited:
+		verdict = VerdictRateLimited
+	case answer == "150":
+	case err != nil:
+		verdict = VerdictWrong
+	case n < 150:
+		verdict = VerdictTooLow
+	default:
+		verdict = VerdictTooHigh
+	}
+	fmt.Fprint(w, page(pages[verdict]))
+}
+
+func (s *standIn) posts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.posted)
+}
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &Client{BaseURL: server.URL, Session: "secret", HTTP: server.Client(), Dir: t.TempDir()}
+}
+
+func TestSubmit(t *testing.T) {
+	site := &standIn{}
+	c := newTestClient(t, site)
+	ctx := context.Background()
+
+	submit := func(answer string, wantVerdict Verdict, wantErr error, wantPosted bool) {
+		t.Helper()
+		before := site.posts()
+		result, err := c.Submit(ctx, 2022, 1, 1, answer)
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("submitting %s: error = %v, want %v", answer, err, wantErr)
+		}
+		if err == nil && result.Verdict != wantVerdict {
+			t.Fatalf("submitting %s: verdict = %s, want %s", answer, result.Verdict, wantVerdict)
+		}
+		if posted := site.posts() > before; posted != wantPosted {
+			t.Fatalf("submitting %s: posted = %v, want %v", answer, posted, wantPosted)
+		}
+	}
+
+	submit("100", VerdictTooLow, nil, true)
+	submit("200", VerdictTooHigh, nil, true)
+	submit("nope", VerdictWrong, nil, true)
+
+	// rejected answers are never resent
+	submit("100", 0, ErrAlreadyRejected, false)
+	submit("200", 0, ErrAlreadyRejected, false)
+	submit("nope", 0, ErrAlreadyRejected, false)
+
+	// the bounds are exclusive: the answer is above 100 and below 200
+	submit("100", 0, ErrAlreadyRejected, false)
+	submit("99", 0, ErrOutOfBounds, false)
+	submit("250", 0, ErrOutOfBounds, false)
+
+	// a rate limited answer wasn't judged, so it can be sent again
+	site.rateLimited = true
+	submit("150", VerdictRateLimited, nil, true)
+	site.rateLimited = false
+
+	submit("101", VerdictTooLow, nil, true)
+	submit("101", 0, ErrAlreadyRejected, false)
+	submit("150", VerdictCorrect, nil, true)
+
+	// once solved, the answer comes from the log and nothing else is sent
+	submit("150", VerdictCorrect, nil, false)
+	submit("151", 0, ErrAlreadySolved, false)
+
+	bounds, err := c.Log().Bounds(2022, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bounds.Range() != "(101, 200)" || bounds.Solved != "150" || bounds.Attempts != 5 {
+		t.Errorf("bounds = %s solved %q after %d attempts, want (101, 200) solved 150 after 5", bounds.Range(), bounds.Solved, bounds.Attempts)
+	}
+}
+
+func TestSubmitForce(t *testing.T) {
+	site := &standIn{}
+	c := newTestClient(t, site)
+	ctx := context.Background()
+
+	if _, err := c.Submit(ctx, 2022, 1, 1, "100"); err != nil {
+		t.Fatal(err)
+	}
+	c.Force = true
+	if result, err := c.Submit(ctx, 2022, 1, 1, "50"); err != nil || result.Verdict != VerdictTooLow {
+		t.Errorf("forced submission = %s, %v, want too low", result.Verdict, err)
+	}
+	// forcing only gets past the bounds, never a rejected answer
+	if _, err := c.Submit(ctx, 2022, 1, 1, "100"); !errors.Is(err, ErrAlreadyRejected) {
+		t.Errorf("forced resubmission error = %v, want ErrAlreadyRejected", err)
+	}
+	if site.posts() != 2 {
+		t.Errorf("posted %v, want 100 then 50", site.posted)
+	}
+}
+
+func TestSubmitErrors(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusInternalServerError)
+	}))
+	if _, err := c.Submit(context.Background(), 2022, 1, 1, "1"); err == nil {
+		t.Error("submitting to a failing server didn't fail")
+	}
+
+	c.Session = ""
+	if _, err := c.Submit(context.Background(), 2022, 1, 1, "1"); !errors.Is(err, ErrNoSession) {
+		t.Errorf("submitting without a session: error = %v, want ErrNoSession", err)
+	}
+}
+
+func TestInput(t *testing.T) {
+	fetches := 0
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2022/day/1/input" {
+			http.NotFound(w, r)
+			return
+		}
+		fetches++
+		fmt.Fprint(w, "1000\n2000\n")
+	}))
+
+	for i := 0; i < 2; i++ {
+		path, err := c.Input(context.Background(), 2022, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data, err := os.ReadFile(path); err != nil || string(data) != "1000\n2000\n" {
+			t.Fatalf("input = %q, %v", data, err)
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("fetched the input %d times, want it cached after once", fetches)
+	}
+
+	if _, err := c.Input(context.Background(), 2022, 2); err == nil {
+		t.Error("fetching a missing input didn't fail")
+	}
+}
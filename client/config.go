@@ -0,0 +1,50 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	ConfigDirEnv = "AOC_CONFIG_DIR"
+	SessionEnv   = "AOC_SESSION"
+	BaseURLEnv   = "AOC_BASE_URL"
+
+	sessionFile = "session"
+)
+
+// ConfigDir is $AOC_CONFIG_DIR, falling back to an "aoc" folder in the
+// user's config directory.
+func ConfigDir() (string, error) {
+	if dir := os.Getenv(ConfigDirEnv); dir != "" {
+		return dir, nil
+	}
+
+	userConfigDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(userConfigDir, "aoc"), nil
+}
+
+// LoadSession reads the session cookie from $AOC_SESSION or the session file
+// in the config directory. A missing session isn't an error here since cached
+// inputs and the submission log are usable without one.
+func LoadSession(dir string) (string, error) {
+	if session := os.Getenv(SessionEnv); session != "" {
+		return session, nil
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, sessionFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const submissionLogFile = "submissions.jsonl"
+
+type Submission struct {
+	Year    int       `json:"year"`
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+}
+
+func (s Submission) Result() Result {
+	return Result{Verdict: s.Verdict, Message: fmt.Sprintf("already submitted on %s", s.Time.Format(time.RFC3339))}
+}
+
+// SubmissionLog is an append-only JSON lines file of every answer sent.
+type SubmissionLog struct {
+	Path string
+}
+
+// NewSubmissionLog is the log kept in a config directory.
+func NewSubmissionLog(dir string) *SubmissionLog {
+	return &SubmissionLog{Path: filepath.Join(dir, submissionLogFile)}
+}
+
+func (l *SubmissionLog) All() ([]Submission, error) {
+	f, err := os.Open(l.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	submissions := []Submission{}
+	scanner := bufio.NewScanner(f)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		s := Submission{}
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", l.Path, lineNumber, err)
+		}
+		submissions = append(submissions, s)
+	}
+
+	return submissions, scanner.Err()
+}
+
+// For returns the submissions for one part, oldest first.
+func (l *SubmissionLog) For(year, day, part int) ([]Submission, error) {
+	all, err := l.All()
+	if err != nil {
+		return nil, err
+	}
+
+	submissions := []Submission{}
+	for _, s := range all {
+		if s.Year == year && s.Day == day && s.Part == part {
+			submissions = append(submissions, s)
+		}
+	}
+	return submissions, nil
+}
+
+// Find returns the most recent judged submission of an answer, or nil if it
+// hasn't been judged. Rate-limited submissions were never judged so they're skipped.
+func (l *SubmissionLog) Find(year, day, part int, answer string) (*Submission, error) {
+	submissions, err := l.For(year, day, part)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Submission
+	for i, s := range submissions {
+		if s.Answer == answer && (s.Verdict == VerdictCorrect || s.Verdict.IsRejection()) {
+			found = &submissions[i]
+		}
+	}
+	return found, nil
+}
+
+func (l *SubmissionLog) Append(s Submission) error {
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Verdict int
+
+const (
+	VerdictUnknown Verdict = iota
+	VerdictCorrect
+	VerdictWrong
+	VerdictTooHigh
+	VerdictTooLow
+	VerdictRateLimited
+	VerdictWrongLevel
+)
+
+var verdictNames = map[Verdict]string{
+	VerdictUnknown:     "unknown",
+	VerdictCorrect:     "correct",
+	VerdictWrong:       "wrong",
+	VerdictTooHigh:     "too high",
+	VerdictTooLow:      "too low",
+	VerdictRateLimited: "rate limited",
+	VerdictWrongLevel:  "wrong level",
+}
+
+func (v Verdict) String() string {
+	if name, hasName := verdictNames[v]; hasName {
+		return name
+	}
+	return fmt.Sprintf("Verdict(%d)", int(v))
+}
+
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *Verdict) UnmarshalText(text []byte) error {
+	for verdict, name := range verdictNames {
+		if name == string(text) {
+			*v = verdict
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown verdict %q", text)
+}
+
+// IsRejection reports whether the answer itself was judged wrong, as opposed
+// to the submission not being judged at all.
+func (v Verdict) IsRejection() bool {
+	return v == VerdictWrong || v == VerdictTooHigh || v == VerdictTooLow
+}
+
+type Result struct {
+	Verdict Verdict
+	// Wait is how long until another answer can be submitted, when rate limited.
+	Wait    time.Duration
+	Message string
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	spacePattern   = regexp.MustCompile(`\s+`)
+	waitPattern    = regexp.MustCompile(`[Yy]ou have (?:(\d+)m )?(\d+)s left to wait`)
+)
+
+// ParseResponse reads the verdict out of the page returned after posting an answer.
+func ParseResponse(page string) Result {
+	message := page
+	if match := articlePattern.FindStringSubmatch(page); match != nil {
+		message = match[1]
+	}
+	message = html.UnescapeString(tagPattern.ReplaceAllString(message, ""))
+	message = strings.TrimSpace(spacePattern.ReplaceAllString(message, " "))
+
+	result := Result{Message: message}
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		result.Verdict = VerdictCorrect
+	case strings.Contains(message, "answer is too high"):
+		result.Verdict = VerdictTooHigh
+	case strings.Contains(message, "answer is too low"):
+		result.Verdict = VerdictTooLow
+	case strings.Contains(message, "That's not the right answer"):
+		result.Verdict = VerdictWrong
+	case strings.Contains(message, "answer too recently"):
+		result.Verdict = VerdictRateLimited
+		result.Wait = parseWait(message)
+	case strings.Contains(message, "solving the right level"):
+		result.Verdict = VerdictWrongLevel
+	}
+
+	return result
+}
+
+func parseWait(message string) time.Duration {
+	match := waitPattern.FindStringSubmatch(message)
+	if match == nil {
+		return 0
+	}
+
+	minutes, _ := strconv.Atoi(match[1])
+	seconds, _ := strconv.Atoi(match[2])
+
+	return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+}
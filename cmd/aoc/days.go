@@ -0,0 +1,26 @@
+package main
+
+// Every day registers itself with the runner when imported.
+import (
+	_ "github.com/Takadimi/aoc/2021/day-1"
+	_ "github.com/Takadimi/aoc/2021/day-12"
+	_ "github.com/Takadimi/aoc/2021/day-2"
+	_ "github.com/Takadimi/aoc/2021/day-25"
+	_ "github.com/Takadimi/aoc/2021/day-3"
+	_ "github.com/Takadimi/aoc/2021/day-4"
+	_ "github.com/Takadimi/aoc/2021/day-5"
+	_ "github.com/Takadimi/aoc/2021/day-6"
+	_ "github.com/Takadimi/aoc/2021/day-7"
+	_ "github.com/Takadimi/aoc/2021/day-8"
+	_ "github.com/Takadimi/aoc/2022/day-1"
+	_ "github.com/Takadimi/aoc/2022/day-10"
+	_ "github.com/Takadimi/aoc/2022/day-11"
+	_ "github.com/Takadimi/aoc/2022/day-2"
+	_ "github.com/Takadimi/aoc/2022/day-3"
+	_ "github.com/Takadimi/aoc/2022/day-4"
+	_ "github.com/Takadimi/aoc/2022/day-5"
+	_ "github.com/Takadimi/aoc/2022/day-6"
+	_ "github.com/Takadimi/aoc/2022/day-7"
+	_ "github.com/Takadimi/aoc/2022/day-8"
+	_ "github.com/Takadimi/aoc/2022/day-9"
+)
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/Takadimi/aoc/checkpoint"
+)
+
+func diffSnapshots(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("expected two snapshots to compare")
+	}
+
+	a, err := checkpoint.Load(positional[0])
+	if err != nil {
+		return err
+	}
+	b, err := checkpoint.Load(positional[1])
+	if err != nil {
+		return err
+	}
+
+	diffs, err := checkpoint.Diff(a, b)
+	if err != nil {
+		return err
+	}
+	for _, d := range diffs {
+		fmt.Println(d)
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("%d differences", len(diffs))
+	}
+	return nil
+}
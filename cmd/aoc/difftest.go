@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Takadimi/aoc/difftest"
+	"github.com/Takadimi/aoc/runner"
+)
+
+func difftestDays(args []string) error {
+	fs := flag.NewFlagSet("difftest", flag.ContinueOnError)
+	all := fs.Bool("all", false, "Check every day that can generate inputs.")
+	part := fs.Int("part", 0, "Only check this part.")
+	count := fs.Int("count", 200, "Number of inputs to generate for each part.")
+	size := fs.Int("size", 50, "Size generated inputs grow to.")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "Seed for generating inputs.")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	days := []runner.Day{}
+	switch {
+	case *all:
+		for _, d := range runner.Days() {
+			if d.Generate != nil {
+				days = append(days, d)
+			}
+		}
+	default:
+		d, err := lookupDay(positional)
+		if err != nil {
+			return err
+		}
+		days = append(days, d)
+	}
+
+	parts := []int{}
+	if *part != 0 {
+		parts = append(parts, *part)
+	}
+	cfg := difftest.Config{Count: *count, MaxSize: *size, Seed: *seed}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("seed %d\n", *seed)
+	disagreements := 0
+	for _, d := range days {
+		reports, err := difftest.Check(ctx, d, parts, cfg)
+		if err != nil {
+			return err
+		}
+		for _, r := range reports {
+			names := strings.Join(r.Names, ", ")
+			if r.Agreed() {
+				fmt.Printf("%s part %d: %s agree on %d inputs\n", d, r.Part, names, r.Checked)
+				continue
+			}
+
+			disagreements++
+			fmt.Printf("%s part %d: %s disagree on input %d, shrunk %d times to:\n", d, r.Part, names, r.Checked, r.Shrinks)
+			for _, l := range r.Input {
+				fmt.Printf("    %s\n", l)
+			}
+			for _, o := range r.Outcomes {
+				fmt.Printf("  %s: %s\n", o.Name, o)
+			}
+		}
+	}
+
+	if disagreements > 0 {
+		return fmt.Errorf("%d parts disagree", disagreements)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Takadimi/aoc/client"
+	"github.com/Takadimi/aoc/input"
+)
+
+func fetch(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	save := fs.Bool("save", false, "Also copy the input into the day's folder as input.txt.")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	year, day, err := parseYearDay(positional)
+	if err != nil {
+		return err
+	}
+
+	c, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	inputPath, err := c.Input(context.Background(), year, day)
+	if err != nil {
+		return err
+	}
+	fmt.Println(inputPath)
+
+	if !*save {
+		return nil
+	}
+
+	dayDir, err := input.DayDir(year, day)
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(inputPath)
+	if err != nil {
+		return err
+	}
+	savedPath := filepath.Join(dayDir, "input.txt")
+	if err := os.WriteFile(savedPath, b, 0o644); err != nil {
+		return err
+	}
+	fmt.Println(savedPath)
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Takadimi/aoc/input"
+)
+
+func gen(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	size := fs.Int("size", 1000, "Scale of the input, in whatever the day counts: lines, boards, dirs, items...")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "Seed for generating the input.")
+	out := fs.String("out", "", "File to write the input to, or - for stdout. Defaults to generated-<size>.txt in the day's directory.")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	d, err := lookupDay(positional)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 2 {
+		return fmt.Errorf("unexpected arguments %v", positional[2:])
+	}
+	if d.Generate == nil {
+		return fmt.Errorf("%s can't generate inputs", d)
+	}
+	if *size < 1 {
+		return fmt.Errorf("invalid size %d", *size)
+	}
+
+	lines := d.Generate(rand.New(rand.NewSource(*seed)), *size)
+	text := strings.Join(lines, "\n") + "\n"
+	if *out == "-" {
+		_, err := fmt.Print(text)
+		return err
+	}
+
+	path := *out
+	if path == "" {
+		dayDir, err := input.DayDir(d.Year, d.Day)
+		if err != nil {
+			return err
+		}
+		path = filepath.Join(dayDir, fmt.Sprintf("generated-%d.txt", *size))
+	}
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "seed %d: wrote %d lines to %s\n", *seed, len(lines), input.RelPath(path))
+	fmt.Fprintf(os.Stderr, "run it with: aoc run %d %d --input %s\n", d.Year, d.Day, input.RelPath(path))
+	return nil
+}
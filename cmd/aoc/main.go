@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"fetch":    {"fetch <year> <day> [--save]", fetch},
+	"submit":   {"submit <year> <day> <part> <answer> [--force]", submit},
+	"status":   {"status", status},
+	"run":      {"run (<year> <day> [--inputs <dir>] | --all [--year <year>]) [--input <name>] [--part <n>] [--workers <n>] [--timeout <d>] [--cpuprofile <file>] [--memprofile <file>] [--trace <file>] [--debug <categories>] [--debug-file <file>] [--debug-level <level>] [--checkpoint-dir <dir> [--checkpoint-every <n>]] [--resume <snapshot>]", run},
+	"diff":     {"diff <snapshot> <snapshot>", diffSnapshots},
+	"gen":      {"gen <year> <day> [--size <n>] [--seed <n>] [--out <file>]", gen},
+	"difftest": {"difftest (<year> <day> | --all) [--part <n>] [--count <n>] [--size <n>] [--seed <n>]", difftestDays},
+	"profile":  {"profile <year> <day> [--part <n>] [--input <name>] [--runs <n>] [--top <n>] [--mem] [--out <file>]", profileDay},
+}
+
+// notes are printed under a command's usage, for what its flags' names
+// don't make clear.
+var notes = map[string][]string{
+	"run": {
+		"--trace writes a runtime execution trace of each part, for go tool trace.",
+		"--debug filters the days' debug events by category, like monkeys.round. It",
+		"isn't called --trace since the execution trace already had that name.",
+	},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, isCommand := commands[os.Args[1]]
+	if !isCommand {
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage:")
+	for _, name := range names {
+		fmt.Fprintln(os.Stderr, "  aoc", commands[name].usage)
+		for _, note := range notes[name] {
+			fmt.Fprintln(os.Stderr, "      ", note)
+		}
+	}
+}
+
+func parseYearDay(args []string) (int, int, error) {
+	if len(args) < 2 {
+		return 0, 0, fmt.Errorf("expected <year> <day>")
+	}
+	year, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid year %q", args[0])
+	}
+	day, err := strconv.Atoi(args[1])
+	if err != nil || day < 1 || day > 25 {
+		return 0, 0, fmt.Errorf("invalid day %q", args[1])
+	}
+	return year, day, nil
+}
+
+// parseArgs parses flags wherever they appear among the positional arguments,
+// so both `aoc fetch --save 2022 1` and `aoc fetch 2022 1 --save` work.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/profile"
+	"github.com/Takadimi/aoc/runner"
+)
+
+func profileDay(args []string) error {
+	fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+	part := fs.Int("part", 1, "Part to profile.")
+	inputName := fs.String("input", input.Real, "Named input (sample, real, sample2, ...) or a path to an input file.")
+	runs := fs.Int("runs", 10, "Number of times to run the part.")
+	top := fs.Int("top", 20, "Number of functions to list.")
+	mem := fs.Bool("mem", false, "Profile memory allocated instead of CPU time.")
+	out := fs.String("out", "", "Also save the raw profile to this file.")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	d, err := lookupDay(positional)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 2 {
+		return fmt.Errorf("unexpected arguments %v", positional[2:])
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	raw := bytes.Buffer{}
+	if err := runner.ProfilePart(ctx, d, *part, *inputName, *runs, *mem, &raw); err != nil {
+		return err
+	}
+	if *out != "" {
+		if err := os.WriteFile(*out, raw.Bytes(), 0o644); err != nil {
+			return err
+		}
+	}
+
+	p, err := profile.Parse(&raw)
+	if err != nil {
+		return err
+	}
+
+	// CPU profiles count samples then nanoseconds, and memory ones end with
+	// the bytes allocated
+	index, unit := len(p.SampleTypes)-1, "ns"
+	if *mem {
+		index, unit = 1, "bytes"
+	}
+	entries, total := p.Top(index, *top)
+	if total == 0 {
+		return fmt.Errorf("no samples collected, try more --runs")
+	}
+
+	fmt.Printf("%s part %d, %d runs, %d %s total\n\n", d, *part, *runs, total, unit)
+	tw := tabwriter.NewWriter(os.Stdout, 1, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "FLAT\tFLAT%\tCUM\tCUM%\t\tFUNCTION")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%d\t%.1f%%\t%d\t%.1f%%\t\t%s\n", e.Flat, percent(e.Flat, total), e.Cum, percent(e.Cum, total), e.Function)
+	}
+	return tw.Flush()
+}
+
+func percent(value, total int64) float64 {
+	return float64(value) * 100 / float64(total)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Takadimi/aoc/checkpoint"
+	"github.com/Takadimi/aoc/input"
+	"github.com/Takadimi/aoc/runner"
+	"github.com/Takadimi/aoc/trace"
+)
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	all := fs.Bool("all", false, "Run every registered day.")
+	year := fs.Int("year", 0, "With --all, only run days from this year.")
+	inputName := fs.String("input", input.Sample, "Named input (sample, real, sample2, ...) or a path to an input file.")
+	inputsDir := fs.String("inputs", "", "Run a day, and every variant of its parts, on each input file in this directory.")
+	part := fs.Int("part", 0, "Only run this part.")
+	workers := fs.Int("workers", runtime.GOMAXPROCS(0), "Number of parts to run at once.")
+	timeout := fs.Duration("timeout", time.Minute, "Give up on a part after this long.")
+	cpuProfile := fs.String("cpuprofile", "", "Write a CPU profile of each part to this file, named by year, day and part.")
+	memProfile := fs.String("memprofile", "", "Write a memory profile of each part to this file, named by year, day and part.")
+	executionTrace := fs.String("trace", "", "Write a runtime execution trace of each part to this file, named by year, day and part. For debug events, see --debug.")
+	debugPatterns := fs.String("debug", "", "Write debug events of these comma separated categories, like monkeys.round or 2022/day-11:*. Not --trace, which is the execution trace.")
+	debugFile := fs.String("debug-file", "", "Write debug events to this file as JSON lines instead of to stderr.")
+	debugLevel := slog.LevelDebug
+	checkpointDir := fs.String("checkpoint-dir", "", "Save snapshots of simulations to this directory as they run and when interrupted.")
+	checkpointEvery := fs.Int("checkpoint-every", 1000, "With --checkpoint-dir, steps between snapshots.")
+	resume := fs.String("resume", "", "Resume the part a snapshot was taken of from it.")
+	fs.TextVar(&debugLevel, "debug-level", slog.LevelDebug, "Only write debug events at or above this level.")
+
+	// a single day's own flags can follow `<year> <day>`
+	days := []runner.Day{}
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		d, err := lookupDay(args)
+		if err != nil {
+			return err
+		}
+		if d.Flags != nil {
+			d.Flags.VisitAll(func(f *flag.Flag) {
+				fs.Var(f.Value, f.Name, f.Usage)
+			})
+		}
+		days = append(days, d)
+		args = args[2:]
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("unexpected arguments %v", positional)
+	}
+
+	if *all {
+		days = []runner.Day{}
+		for _, d := range runner.Days() {
+			if *year == 0 || d.Year == *year {
+				days = append(days, d)
+			}
+		}
+	}
+	if len(days) == 0 {
+		return fmt.Errorf("expected <year> <day> or --all")
+	}
+
+	opts := runner.Options{
+		Input:   *inputName,
+		Workers: *workers,
+		Timeout: *timeout,
+		Profiles: runner.Profiles{
+			CPU:   *cpuProfile,
+			Mem:   *memProfile,
+			Trace: *executionTrace,
+		},
+		Checkpoints: runner.Checkpoints{
+			Dir:   *checkpointDir,
+			Every: *checkpointEvery,
+		},
+	}
+	// expected answers are for the puzzle as asked, not as a day's flags
+	// might change it
+	fs.Visit(func(f *flag.Flag) {
+		for _, d := range days {
+			if d.Flags != nil && d.Flags.Lookup(f.Name) != nil {
+				opts.SkipExpected = true
+			}
+		}
+	})
+	if *part != 0 {
+		opts.Parts = []int{*part}
+	}
+	if *resume != "" {
+		snapshot, err := checkpoint.Load(*resume)
+		if err != nil {
+			return err
+		}
+		// a snapshot no part picks up would otherwise be ignored, starting
+		// a long run over from scratch
+		if !runner.Runs(days, opts.Parts, snapshot.Year, snapshot.Day, snapshot.Part) {
+			return fmt.Errorf("%s is a snapshot of %d day %d part %d, which isn't being run", *resume, snapshot.Year, snapshot.Day, snapshot.Part)
+		}
+		opts.Checkpoints.Resume = snapshot
+	}
+
+	if *debugPatterns != "" {
+		closeTrace, err := enableTrace(*debugPatterns, *debugFile, debugLevel)
+		if err != nil {
+			return err
+		}
+		defer closeTrace()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *inputsDir != "" {
+		if len(days) != 1 {
+			return fmt.Errorf("--inputs runs a single <year> <day>")
+		}
+		if opts.Profiles.Enabled() || *checkpointDir != "" || *resume != "" {
+			return fmt.Errorf("--inputs can't be combined with profiling or checkpoints")
+		}
+		return runInputs(ctx, days[0], *inputsDir, opts)
+	}
+
+	results := runner.Run(ctx, days, opts)
+	if err := runner.PrintSummary(os.Stdout, results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Failed() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d parts failed", failed, len(results))
+	}
+
+	return nil
+}
+
+// runInputs runs a day on every input in a directory, printing a matrix of
+// answers and failing if any input needs a look.
+func runInputs(ctx context.Context, d runner.Day, dir string, opts runner.Options) error {
+	results, err := runner.RunInputs(ctx, d, dir, opts)
+	if err != nil {
+		return err
+	}
+	if err := runner.PrintMatrix(os.Stdout, results); err != nil {
+		return err
+	}
+
+	if flagged := runner.Flagged(results); len(flagged) > 0 {
+		return fmt.Errorf("%d of the inputs need a look", len(flagged))
+	}
+	return nil
+}
+
+// enableTrace switches on the debug events matching patterns, sending them
+// to stderr or a JSON lines file. It returns what closes the file.
+func enableTrace(patterns, file string, level slog.Level) (func() error, error) {
+	ps, err := trace.ParsePatterns(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	if file == "" {
+		trace.Enable(slog.NewTextHandler(os.Stderr, handlerOpts), ps...)
+		return func() error { return nil }, nil
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, err
+	}
+	trace.Enable(slog.NewJSONHandler(f, handlerOpts), ps...)
+	return func() error {
+		trace.Disable()
+		return f.Close()
+	}, nil
+}
+
+func lookupDay(args []string) (runner.Day, error) {
+	year, day, err := parseYearDay(args)
+	if err != nil {
+		return runner.Day{}, err
+	}
+
+	d, isRegistered := runner.Lookup(year, day)
+	if !isRegistered {
+		return runner.Day{}, fmt.Errorf("no solution registered for %d day %d", year, day)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Takadimi/aoc/client"
+	"github.com/Takadimi/aoc/input"
+)
+
+func status(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	dir, err := client.ConfigDir()
+	if err != nil {
+		return err
+	}
+	submissions, err := client.NewSubmissionLog(dir).All()
+	if err != nil {
+		return err
+	}
+
+	// every day folder gets a row, plus any day that only shows up in the log
+	days, err := input.Days()
+	if err != nil {
+		return err
+	}
+	seen := map[input.YearDay]bool{}
+	for _, d := range days {
+		seen[d] = true
+	}
+	for _, s := range submissions {
+		d := input.YearDay{Year: s.Year, Day: s.Day}
+		if !seen[d] {
+			seen[d] = true
+			days = append(days, d)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Year != days[j].Year {
+			return days[i].Year < days[j].Year
+		}
+		return days[i].Day < days[j].Day
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 1, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "YEAR\tDAY\tPART\tATTEMPTS\tBOUNDS\tREJECTED\tSOLVED")
+	for _, d := range days {
+		for part := 1; part <= 2; part++ {
+			partSubmissions := []client.Submission{}
+			for _, s := range submissions {
+				if s.Year == d.Year && s.Day == d.Day && s.Part == part {
+					partSubmissions = append(partSubmissions, s)
+				}
+			}
+			b := client.NewBounds(partSubmissions)
+
+			rejected := "-"
+			if len(b.Rejected) > 0 {
+				rejected = strings.Join(b.Rejected, ",")
+			}
+			solved := "-"
+			if b.Solved != "" {
+				solved = b.Solved
+			}
+			fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%s\t%s\t%s\n", d.Year, d.Day, part, b.Attempts, b.Range(), rejected, solved)
+		}
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/Takadimi/aoc/client"
+)
+
+func submit(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	force := fs.Bool("force", false, "Submit even if the answer is outside the bounds learned from earlier feedback.")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 4 {
+		return fmt.Errorf("expected <year> <day> <part> <answer>")
+	}
+	year, day, err := parseYearDay(positional)
+	if err != nil {
+		return err
+	}
+	part, err := strconv.Atoi(positional[2])
+	if err != nil || (part != 1 && part != 2) {
+		return fmt.Errorf("invalid part %q", positional[2])
+	}
+	answer := positional[3]
+
+	c, err := client.New()
+	if err != nil {
+		return err
+	}
+	c.Force = *force
+
+	bounds, err := c.Log().Bounds(year, day, part)
+	if err != nil {
+		return err
+	}
+	if err := bounds.Check(answer); errors.Is(err, client.ErrOutOfBounds) {
+		if !*force {
+			return fmt.Errorf("%w (use --force to submit anyway)", err)
+		}
+		fmt.Println("warning:", err)
+	}
+
+	result, err := c.Submit(context.Background(), year, day, part, answer)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %s\n", result.Verdict, result.Message)
+	if result.Verdict == client.VerdictRateLimited {
+		fmt.Println("try again in", result.Wait)
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+// Package combin enumerates permutations, combinations, subsets and
+// products without building them all up front.
+//
+// Generators hand each arrangement to a yield callback, reusing the same
+// slice every time, so callbacks must copy anything they want to keep.
+// Returning false from yield stops the generator early, and generators
+// report whether they ran to the end.
+package combin
+
+import (
+	"sort"
+
+	"github.com/Takadimi/aoc/seq"
+)
+
+// Permutations yields every ordering of s, n! in all, using Heap's
+// algorithm so each one differs from the last by a single swap. s itself is
+// left alone.
+func Permutations[T any](s []T, yield func([]T) bool) bool {
+	a := append([]T(nil), s...)
+	if !yield(a) {
+		return false
+	}
+
+	// c is the loop counter of each level of the recursive algorithm
+	c := make([]int, len(a))
+	for i := 1; i < len(a); {
+		if c[i] < i {
+			if i%2 == 0 {
+				a[0], a[i] = a[i], a[0]
+			} else {
+				a[c[i]], a[i] = a[i], a[c[i]]
+			}
+			if !yield(a) {
+				return false
+			}
+			c[i]++
+			i = 1
+		} else {
+			c[i] = 0
+			i++
+		}
+	}
+
+	return true
+}
+
+// Combinations yields every way of choosing k elements of s, keeping them
+// in the order they are in s, in lexicographic order of their indices.
+func Combinations[T any](s []T, k int, yield func([]T) bool) bool {
+	if k < 0 || k > len(s) {
+		return true
+	}
+
+	indices := make([]int, k)
+	for i := range indices {
+		indices[i] = i
+	}
+	chosen := make([]T, k)
+
+	for {
+		for i, index := range indices {
+			chosen[i] = s[index]
+		}
+		if !yield(chosen) {
+			return false
+		}
+
+		// move the rightmost index that still can along by one, and
+		// every index after it to just behind it
+		i := k - 1
+		for i >= 0 && indices[i] == len(s)-k+i {
+			i--
+		}
+		if i < 0 {
+			return true
+		}
+		indices[i]++
+		for j := i + 1; j < k; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+}
+
+// Subsets yields all 2^n subsets of s, smallest first, starting with the
+// empty set.
+func Subsets[T any](s []T, yield func([]T) bool) bool {
+	for k := 0; k <= len(s); k++ {
+		if !Combinations(s, k, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// Product yields every way of picking one element from each of sets, the
+// last set varying fastest. There's nothing to yield if any set is empty.
+func Product[T any](sets [][]T, yield func([]T) bool) bool {
+	for _, set := range sets {
+		if len(set) == 0 {
+			return true
+		}
+	}
+
+	indices := make([]int, len(sets))
+	picked := make([]T, len(sets))
+	for {
+		for i, index := range indices {
+			picked[i] = sets[i][index]
+		}
+		if !yield(picked) {
+			return false
+		}
+
+		// the odometer rolls over from the right
+		i := len(sets) - 1
+		for i >= 0 && indices[i] == len(sets[i])-1 {
+			indices[i] = 0
+			i--
+		}
+		if i < 0 {
+			return true
+		}
+		indices[i]++
+	}
+}
+
+// MultisetPermutations yields the distinct orderings of s, in lexicographic
+// order, so repeated elements don't produce the same ordering twice.
+func MultisetPermutations[T seq.Ordered](s []T, yield func([]T) bool) bool {
+	a := append([]T(nil), s...)
+	sort.Slice(a, func(i, j int) bool {
+		return a[i] < a[j]
+	})
+
+	for {
+		if !yield(a) {
+			return false
+		}
+		if !nextPermutation(a) {
+			return true
+		}
+	}
+}
+
+// nextPermutation rearranges a into the next ordering in lexicographic
+// order, returning false when a is already the last one.
+func nextPermutation[T seq.Ordered](a []T) bool {
+	i := len(a) - 2
+	for i >= 0 && a[i] >= a[i+1] {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+
+	j := len(a) - 1
+	for a[j] <= a[i] {
+		j--
+	}
+	a[i], a[j] = a[j], a[i]
+
+	for l, r := i+1, len(a)-1; l < r; l, r = l+1, r-1 {
+		a[l], a[r] = a[r], a[l]
+	}
+	return true
+}
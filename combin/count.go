@@ -0,0 +1,67 @@
+package combin
+
+import "math/big"
+
+// The counts are worked out exactly with big integers. They report false
+// when the count doesn't fit in an int rather than wrapping around.
+
+// Factorial is n!, the number of permutations of n elements.
+func Factorial(n int) (int, bool) {
+	if n < 0 {
+		return 0, false
+	}
+	return fit(new(big.Int).MulRange(1, int64(n)))
+}
+
+// Binomial is n choose k, the number of k-combinations of n elements.
+func Binomial(n, k int) (int, bool) {
+	if k < 0 || n < 0 || k > n {
+		return 0, true
+	}
+	return fit(new(big.Int).Binomial(int64(n), int64(k)))
+}
+
+// SubsetCount is 2^n, the number of subsets of n elements.
+func SubsetCount(n int) (int, bool) {
+	if n < 0 {
+		return 0, false
+	}
+	return fit(new(big.Int).Lsh(big.NewInt(1), uint(n)))
+}
+
+// ProductCount is the number of ways to pick one element from each of sets
+// of the given sizes.
+func ProductCount(sizes ...int) (int, bool) {
+	product := big.NewInt(1)
+	for _, size := range sizes {
+		if size < 0 {
+			return 0, false
+		}
+		product.Mul(product, big.NewInt(int64(size)))
+	}
+	return fit(product)
+}
+
+// MultisetPermutationCount is the number of distinct orderings of a
+// multiset holding each of its distinct elements the given number of times:
+// (Σ counts)! / Π counts!.
+func MultisetPermutationCount(counts ...int) (int, bool) {
+	total := int64(0)
+	denominator := big.NewInt(1)
+	for _, count := range counts {
+		if count < 0 {
+			return 0, false
+		}
+		total += int64(count)
+		denominator.Mul(denominator, new(big.Int).MulRange(1, int64(count)))
+	}
+	numerator := new(big.Int).MulRange(1, total)
+	return fit(numerator.Quo(numerator, denominator))
+}
+
+func fit(n *big.Int) (int, bool) {
+	if !n.IsInt64() || int64(int(n.Int64())) != n.Int64() {
+		return 0, false
+	}
+	return int(n.Int64()), true
+}
@@ -7,27 +7,36 @@ if [[ -z $DAY ]]; then
     exit 1
 fi
 
-
+# run from inside a year folder (e.g. 2022/)
+YEAR=$(basename "$PWD")
 
 mkdir day-$DAY
 (
     cd day-$DAY &&
-    touch main.go sample.txt input.txt &&
-    cat << EOF > main.go
-package main
+    touch solution.go sample.txt input.txt &&
+    cat << EOF > solution.go
+package day$DAY
 
 import (
-	"flag"
-	"fmt"
+	"github.com/Takadimi/aoc/runner"
 )
 
-var inputFileFlag = flag.String("inputFile", "sample.txt", "Relative file path to use as input.")
-
-func main() {
-	flag.Parse()
-	inputFile := *inputFileFlag
+func init() {
+	runner.Register(runner.Day{
+		Year: $YEAR,
+		Day:  $DAY,
+		Parts: []runner.PartFunc{
+			runner.SolveLines(partOne),
+		},
+	})
+}
 
-    fmt.Println(inputFile)
+func partOne(lines []string) int {
+	return len(lines)
 }
 EOF
 )
+
+# register the new day with the aoc command
+sed -i "s#^)\$#\t_ \"github.com/Takadimi/aoc/$YEAR/day-$DAY\"\n)#" ../cmd/aoc/days.go
+gofmt -w ../cmd/aoc/days.go
@@ -0,0 +1,91 @@
+// Package cycle finds where a simulation starts repeating itself so it can
+// skip ahead instead of stepping all the way to a far off step.
+package cycle
+
+import "context"
+
+// Cycle is where a run of states starts repeating: the state after
+// Start+Length steps is the same as the one after Start steps.
+type Cycle struct {
+	Start, Length int
+}
+
+// History is every state Find stepped through, starting with the initial
+// state.
+type History[S any] struct {
+	States []S
+	// Cycle is only meaningful when Found is set. States then ends with the
+	// state that repeated the one at Cycle.Start.
+	Cycle Cycle
+	Found bool
+}
+
+// Find steps from initial until it reaches a state whose key it has seen
+// before. Keys only need to capture what decides the following states, so
+// anything that just accumulates (counts, totals) can be left out of them.
+// It gives up looking after limit steps, or never when limit is negative,
+// returning the states so far with Found unset. step must not modify the
+// state it's given.
+func Find[S any, K comparable](ctx context.Context, initial S, step func(S) S, key func(S) K, limit int) (History[S], error) {
+	h := History[S]{States: []S{initial}}
+	seen := map[K]int{key(initial): 0}
+
+	state := initial
+	for n := 1; limit < 0 || n <= limit; n++ {
+		if err := ctx.Err(); err != nil {
+			return h, err
+		}
+
+		state = step(state)
+		h.States = append(h.States, state)
+
+		k := key(state)
+		if start, isSeen := seen[k]; isSeen {
+			h.Cycle = Cycle{Start: start, Length: n - start}
+			h.Found = true
+			return h, nil
+		}
+		seen[k] = n
+	}
+
+	return h, nil
+}
+
+// Index maps step n onto the index of an equivalent state in States, or
+// false when n is past the last state and no cycle was found.
+func (h History[S]) Index(n int) (int, bool) {
+	if n < len(h.States) {
+		return n, true
+	}
+	if !h.Found {
+		return 0, false
+	}
+	return h.Cycle.Start + (n-h.Cycle.Start)%h.Cycle.Length, true
+}
+
+// At is the state after n steps. See Index.
+func (h History[S]) At(n int) (S, bool) {
+	i, isKnown := h.Index(n)
+	if !isKnown {
+		var zero S
+		return zero, false
+	}
+	return h.States[i], true
+}
+
+// Extrapolate is the value of metric after n steps, for a metric that grows
+// by the same amount every time round the cycle, like a running count.
+// Metrics that only depend on the key can just use At.
+func (h History[S]) Extrapolate(metric func(S) int, n int) (int, bool) {
+	if n < len(h.States) {
+		return metric(h.States[n]), true
+	}
+	if !h.Found {
+		return 0, false
+	}
+
+	start, length := h.Cycle.Start, h.Cycle.Length
+	perCycle := metric(h.States[start+length]) - metric(h.States[start])
+	cycles, offset := (n-start)/length, (n-start)%length
+	return metric(h.States[start+offset]) + cycles*perCycle, true
+}
@@ -0,0 +1,127 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// mod steps through x*x+1 mod m, which runs into a cycle after a tail that
+// depends on where it starts.
+func mod(m int) func(int) int {
+	return func(x int) int { return (x*x + 1) % m }
+}
+
+func identity(x int) int { return x }
+
+func find(t *testing.T, initial int, step func(int) int, limit int) History[int] {
+	t.Helper()
+	h, err := Find(context.Background(), initial, step, identity, limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial int
+		step    func(int) int
+		want    Cycle
+	}{
+		// 0 1 2 5 26 677 ... -> mod 10: 0 1 2 5 6 7 0
+		{"no tail", 0, mod(10), Cycle{Start: 0, Length: 6}},
+		// 3 0 1 2 5 6 7 0
+		{"tail", 3, mod(10), Cycle{Start: 1, Length: 6}},
+		{"period of 1", 7, func(x int) int { return min(x+1, 9) }, Cycle{Start: 2, Length: 1}},
+		{"fixed point", 4, identity, Cycle{Start: 0, Length: 1}},
+	}
+	for _, test := range tests {
+		h := find(t, test.initial, test.step, -1)
+		if !h.Found || h.Cycle != test.want {
+			t.Errorf("%s: found %v %+v, want %+v", test.name, h.Found, h.Cycle, test.want)
+			continue
+		}
+		// States ends with the state that repeats the start of the cycle
+		if last := h.States[len(h.States)-1]; last != h.States[h.Cycle.Start] {
+			t.Errorf("%s: states end with %d, want %d", test.name, last, h.States[h.Cycle.Start])
+		}
+	}
+}
+
+func TestFindLimit(t *testing.T) {
+	h := find(t, 0, func(x int) int { return x + 1 }, 5)
+	if h.Found || len(h.States) != 6 {
+		t.Fatalf("found %v with %d states, want 6 states and none found", h.Found, len(h.States))
+	}
+	if _, isKnown := h.At(5); !isKnown {
+		t.Error("state 5 is unknown")
+	}
+	if _, isKnown := h.At(6); isKnown {
+		t.Error("state 6 is known past the limit without a cycle")
+	}
+	if _, isKnown := h.Extrapolate(identity, 6); isKnown {
+		t.Error("extrapolated past the limit without a cycle")
+	}
+}
+
+func TestFindCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Find(ctx, 0, func(x int) int { return x + 1 }, identity, -1)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
+
+// TestAt checks skipping ahead against stepping all the way there.
+func TestAt(t *testing.T) {
+	for _, initial := range []int{0, 3, 4, 8} {
+		step := mod(10)
+		h := find(t, initial, step, -1)
+		state := initial
+		for n := 0; n < 100; n++ {
+			if got, isKnown := h.At(n); !isKnown || got != state {
+				t.Errorf("from %d, At(%d) = %d, %v, want %d", initial, n, got, isKnown, state)
+			}
+			state = step(state)
+		}
+	}
+}
+
+func TestExtrapolate(t *testing.T) {
+	// a running total alongside the state, which the key leaves out
+	type counted struct{ x, total int }
+	step := func(c counted) counted {
+		next := mod(10)(c.x)
+		return counted{next, c.total + next}
+	}
+	key := func(c counted) int { return c.x }
+	total := func(c counted) int { return c.total }
+
+	for _, initial := range []int{0, 3} {
+		h, err := Find(context.Background(), counted{initial, initial}, step, key, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := counted{initial, initial}
+		for n := 0; n < 100; n++ {
+			if got, isKnown := h.Extrapolate(total, n); !isKnown || got != state.total {
+				t.Errorf("from %d, total after %d steps = %d, %v, want %d", initial, n, got, isKnown, state.total)
+			}
+			state = step(state)
+		}
+	}
+
+	// with a period of 1 the metric grows by the same amount every step
+	h, err := Find(context.Background(), counted{9, 0}, func(c counted) counted {
+		return counted{c.x, c.total + c.x}
+	}, key, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := h.Extrapolate(total, 1000); got != 9000 {
+		t.Errorf("total after 1000 steps of a fixed point = %d, want 9000", got)
+	}
+}
@@ -0,0 +1,175 @@
+// Package difftest checks a day's parts against their variants on generated
+// inputs, the way testing/quick checks properties, and shrinks any input
+// they disagree on down to the smallest one it can find.
+package difftest
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime/debug"
+
+	"github.com/Takadimi/aoc/runner"
+)
+
+// Implementation is one named way of solving a part.
+type Implementation struct {
+	Name string
+	Func runner.PartFunc
+}
+
+// Implementations lists the ways a day solves a part, the registered part
+// first, named "part", followed by its variants.
+func Implementations(d runner.Day, part int) []Implementation {
+	impls := []Implementation{{Name: "part", Func: d.Parts[part-1]}}
+	for _, v := range d.Variants {
+		if v.Part == part {
+			impls = append(impls, Implementation{Name: v.Name, Func: v.Func})
+		}
+	}
+	return impls
+}
+
+type Config struct {
+	// Count is how many inputs to generate for each part.
+	Count int
+	// MaxSize is the size inputs grow to over the run, starting small so
+	// the simplest disagreements turn up first.
+	MaxSize int
+	// Seed seeds the generator, so a run can be repeated.
+	Seed int64
+}
+
+// Outcome is what an implementation made of an input.
+type Outcome struct {
+	Name   string
+	Answer string
+	Err    error
+}
+
+func (o Outcome) String() string {
+	if o.Err != nil {
+		return "error: " + o.Err.Error()
+	}
+	return o.Answer
+}
+
+// Report is how a part's implementations fared. Input is only set if they
+// disagreed, and is then the smallest input found that they disagree on,
+// which can be empty.
+type Report struct {
+	Part      int
+	Names     []string
+	Checked   int
+	Disagreed bool
+	Input     []string
+	Outcomes  []Outcome
+	// Shrinks counts the smaller inputs the disagreement was narrowed to.
+	Shrinks int
+}
+
+func (r Report) Agreed() bool {
+	return !r.Disagreed
+}
+
+// Check runs each of a day's parts that has variants against its variants
+// on generated inputs.
+func Check(ctx context.Context, d runner.Day, parts []int, cfg Config) ([]Report, error) {
+	if d.Generate == nil {
+		return nil, fmt.Errorf("%s can't generate inputs", d)
+	}
+
+	reports := []Report{}
+	for part := 1; part <= len(d.Parts); part++ {
+		if !wantsPart(parts, part) {
+			continue
+		}
+		impls := Implementations(d, part)
+		if len(impls) < 2 {
+			continue
+		}
+
+		r, err := checkPart(ctx, d, part, impls, cfg)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+func checkPart(ctx context.Context, d runner.Day, part int, impls []Implementation, cfg Config) (Report, error) {
+	r := Report{Part: part}
+	for _, impl := range impls {
+		r.Names = append(r.Names, impl.Name)
+	}
+
+	// every part gets the same inputs for a seed, whichever parts are run
+	rng := rand.New(rand.NewSource(cfg.Seed + int64(part)))
+	for i := 0; i < cfg.Count; i++ {
+		if err := ctx.Err(); err != nil {
+			return r, err
+		}
+		r.Checked++
+
+		size := 1 + cfg.MaxSize*i/cfg.Count
+		lines := d.Generate(rng, size)
+		outcomes := run(ctx, impls, lines)
+		if agree(outcomes) {
+			continue
+		}
+
+		r.Disagreed = true
+		r.Input, r.Outcomes, r.Shrinks = shrink(ctx, impls, lines, outcomes)
+		return r, nil
+	}
+	return r, nil
+}
+
+func run(ctx context.Context, impls []Implementation, lines []string) []Outcome {
+	outcomes := []Outcome{}
+	for _, impl := range impls {
+		answer, err := call(ctx, impl.Func, lines)
+		o := Outcome{Name: impl.Name, Err: err}
+		if err == nil {
+			o.Answer = fmt.Sprint(answer)
+		}
+		outcomes = append(outcomes, o)
+	}
+	return outcomes
+}
+
+// call runs an implementation on its own copy of the lines, turning a panic
+// into an error so it counts as disagreeing rather than stopping the run.
+func call(ctx context.Context, f runner.PartFunc, lines []string) (answer any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &runner.PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return f(ctx, append([]string(nil), lines...))
+}
+
+// agree reports whether every implementation came to the same answer, or
+// all failed. Failures only agree with each other, whatever their errors
+// say, since variants are free to word them differently.
+func agree(outcomes []Outcome) bool {
+	for _, o := range outcomes[1:] {
+		if (o.Err == nil) != (outcomes[0].Err == nil) || o.Answer != outcomes[0].Answer {
+			return false
+		}
+	}
+	return true
+}
+
+func wantsPart(parts []int, part int) bool {
+	if len(parts) == 0 {
+		return true
+	}
+	for _, p := range parts {
+		if p == part {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package difftest_test
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Takadimi/aoc/difftest"
+	"github.com/Takadimi/aoc/runner"
+
+	// every day that generates inputs registers itself when imported
+	_ "github.com/Takadimi/aoc/2021/day-1"
+	_ "github.com/Takadimi/aoc/2021/day-12"
+	_ "github.com/Takadimi/aoc/2021/day-2"
+	_ "github.com/Takadimi/aoc/2021/day-25"
+	_ "github.com/Takadimi/aoc/2021/day-3"
+	_ "github.com/Takadimi/aoc/2021/day-4"
+	_ "github.com/Takadimi/aoc/2021/day-5"
+	_ "github.com/Takadimi/aoc/2021/day-6"
+	_ "github.com/Takadimi/aoc/2021/day-7"
+	_ "github.com/Takadimi/aoc/2021/day-8"
+	_ "github.com/Takadimi/aoc/2022/day-1"
+	_ "github.com/Takadimi/aoc/2022/day-10"
+	_ "github.com/Takadimi/aoc/2022/day-11"
+	_ "github.com/Takadimi/aoc/2022/day-2"
+	_ "github.com/Takadimi/aoc/2022/day-3"
+	_ "github.com/Takadimi/aoc/2022/day-4"
+	_ "github.com/Takadimi/aoc/2022/day-5"
+	_ "github.com/Takadimi/aoc/2022/day-6"
+	_ "github.com/Takadimi/aoc/2022/day-7"
+	_ "github.com/Takadimi/aoc/2022/day-8"
+	_ "github.com/Takadimi/aoc/2022/day-9"
+)
+
+// TestVariantsAgree checks every day's variants against its parts on a few
+// generated inputs, with a fixed seed so a failure can be repeated with
+// `aoc difftest --seed 1`.
+func TestVariantsAgree(t *testing.T) {
+	cfg := difftest.Config{Count: 20, MaxSize: 20, Seed: 1}
+	if testing.Short() {
+		cfg.Count = 5
+	}
+
+	checked := 0
+	for _, d := range runner.Days() {
+		if d.Generate == nil {
+			continue
+		}
+		d := d
+		t.Run(d.String(), func(t *testing.T) {
+			reports, err := difftest.Check(context.Background(), d, nil, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range reports {
+				checked++
+				if r.Agreed() {
+					continue
+				}
+				t.Errorf("part %d: %s disagree on %q: %v", r.Part, strings.Join(r.Names, ", "), r.Input, r.Outcomes)
+			}
+		})
+	}
+	if checked == 0 {
+		t.Error("no day has variants to check")
+	}
+}
+
+// TestDisagreeOnEmptyInput checks a disagreement on an input with no lines
+// still shows as one.
+func TestDisagreeOnEmptyInput(t *testing.T) {
+	d := runner.Day{
+		Year: 2000,
+		Day:  1,
+		Parts: []runner.PartFunc{
+			runner.SolveLines(func(lines []string) int { return len(lines) }),
+		},
+		Variants: []runner.Variant{{
+			Part: 1,
+			Name: "off by one",
+			Func: runner.SolveLines(func(lines []string) int { return len(lines) + 1 }),
+		}},
+		Generate: func(r *rand.Rand, size int) []string {
+			return nil
+		},
+	}
+
+	reports, err := difftest.Check(context.Background(), d, nil, difftest.Config{Count: 1, MaxSize: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 || reports[0].Agreed() {
+		t.Fatalf("expected a disagreement, got %+v", reports)
+	}
+}
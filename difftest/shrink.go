@@ -0,0 +1,169 @@
+package difftest
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// shrink narrows down an input the implementations disagree on, trying
+// ever smaller inputs and keeping any they still disagree on. Inputs the
+// registered part fails on aren't kept, so the input stays a valid one.
+func shrink(ctx context.Context, impls []Implementation, lines []string, outcomes []Outcome) ([]string, []Outcome, int) {
+	shrinks := 0
+	for ctx.Err() == nil {
+		shrunk := false
+		for _, candidate := range candidates(lines) {
+			if ctx.Err() != nil {
+				break
+			}
+			candidateOutcomes := run(ctx, impls, candidate)
+			if candidateOutcomes[0].Err == nil && !agree(candidateOutcomes) {
+				lines, outcomes = candidate, candidateOutcomes
+				shrinks++
+				shrunk = true
+				break
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	return lines, outcomes, shrinks
+}
+
+// candidates lists smaller versions of an input, biggest cuts first: whole
+// runs of lines, then columns of a grid, then single fields, then smaller
+// numbers.
+func candidates(lines []string) [][]string {
+	cs := [][]string{}
+
+	for size := len(lines) / 2; size >= 1; size /= 2 {
+		for start := 0; start+size <= len(lines); start += size {
+			cs = append(cs, splice(lines, start, start+size))
+		}
+	}
+
+	if isGrid(lines) && len(lines[0]) > 1 {
+		for x := 0; x < len(lines[0]); x++ {
+			c := []string{}
+			for _, l := range lines {
+				c = append(c, l[:x]+l[x+1:])
+			}
+			cs = append(cs, c)
+		}
+	}
+
+	for i, l := range lines {
+		fields := splitFields(l)
+		if len(fields) > 1 {
+			for size := len(fields) / 2; size >= 1; size /= 2 {
+				for start := 0; start+size <= len(fields); start += size {
+					cs = append(cs, replaceLine(lines, i, joinFields(splice(fields, start, start+size))))
+				}
+			}
+		}
+
+		for j, f := range fields {
+			for _, smaller := range smallerNumbers(f.text) {
+				shrunk := append([]field(nil), fields...)
+				shrunk[j].text = smaller
+				cs = append(cs, replaceLine(lines, i, joinFields(shrunk)))
+			}
+		}
+	}
+
+	return cs
+}
+
+func splice[T any](s []T, start, end int) []T {
+	return append(append([]T(nil), s[:start]...), s[end:]...)
+}
+
+func replaceLine(lines []string, i int, line string) []string {
+	c := append([]string(nil), lines...)
+	c[i] = line
+	return c
+}
+
+// isGrid reports whether the lines are a rectangle of characters with no
+// separators, like a map.
+func isGrid(lines []string) bool {
+	if len(lines) == 0 {
+		return false
+	}
+	for _, l := range lines {
+		if len(l) != len(lines[0]) || strings.ContainsAny(l, " ,") {
+			return false
+		}
+	}
+	return true
+}
+
+// field is part of a line along with the separator that follows it.
+type field struct {
+	text, sep string
+}
+
+// splitFields splits a line at commas and spaces, or into single
+// characters if it has neither.
+func splitFields(line string) []field {
+	fields := []field{}
+	if !strings.ContainsAny(line, " ,") {
+		for _, r := range line {
+			fields = append(fields, field{text: string(r)})
+		}
+		return fields
+	}
+
+	start := 0
+	for i := 0; i <= len(line); i++ {
+		if i < len(line) && line[i] != ',' && line[i] != ' ' {
+			continue
+		}
+		// runs of separators stay with the field before them
+		end := i
+		for i < len(line) && (line[i] == ',' || line[i] == ' ') {
+			i++
+		}
+		fields = append(fields, field{text: line[start:end], sep: line[end:i]})
+		start = i
+	}
+	return fields
+}
+
+func joinFields(fields []field) string {
+	sb := strings.Builder{}
+	for i, f := range fields {
+		sb.WriteString(f.text)
+		// the last field keeps no trailing separator once others are cut
+		if i < len(fields)-1 {
+			sb.WriteString(f.sep)
+		}
+	}
+	return sb.String()
+}
+
+// smallerNumbers is what a number could shrink to, heading for zero.
+func smallerNumbers(s string) []string {
+	if s == "" || !unicode.IsDigit(rune(s[len(s)-1])) {
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n == 0 {
+		return nil
+	}
+	if n < 0 {
+		return []string{"0", strconv.Itoa(-n)}
+	}
+
+	smaller := []string{"0"}
+	if n/2 != 0 {
+		smaller = append(smaller, strconv.Itoa(n/2))
+	}
+	if n-1 != 0 && n-1 != n/2 {
+		smaller = append(smaller, strconv.Itoa(n-1))
+	}
+	return smaller
+}
@@ -0,0 +1,199 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	ErrDivisionByZero = errors.New("division by zero")
+	ErrUnbound        = errors.New("unbound variable")
+	ErrOverflow       = errors.New("integer overflow")
+)
+
+const minInt = -1 << (strconv.IntSize - 1)
+
+// Env binds variable names to their values.
+type Env map[string]int
+
+type Node interface {
+	Eval(env Env) (int, error)
+	// String renders the node fully parenthesised.
+	String() string
+}
+
+type Num struct {
+	Value int
+}
+
+type Var struct {
+	Name string
+	Pos  int
+}
+
+type Negate struct {
+	X   Node
+	Pos int
+}
+
+type Binary struct {
+	Op          string
+	Left, Right Node
+	// Pos is where the operator is in the source.
+	Pos int
+}
+
+func (n *Num) Eval(env Env) (int, error) {
+	return n.Value, nil
+}
+
+func (n *Num) String() string {
+	return strconv.Itoa(n.Value)
+}
+
+func (v *Var) Eval(env Env) (int, error) {
+	value, isBound := env[v.Name]
+	if !isBound {
+		return 0, fmt.Errorf("%w %s", ErrUnbound, v.Name)
+	}
+	return value, nil
+}
+
+func (v *Var) String() string {
+	return v.Name
+}
+
+func (n *Negate) Eval(env Env) (int, error) {
+	x, err := n.X.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	return negate(x)
+}
+
+func (n *Negate) String() string {
+	return "(-" + n.X.String() + ")"
+}
+
+func (b *Binary) Eval(env Env) (int, error) {
+	left, err := b.Left.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	right, err := b.Right.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	return apply(b.Op, left, right)
+}
+
+func (b *Binary) String() string {
+	return "(" + b.Left.String() + " " + b.Op + " " + b.Right.String() + ")"
+}
+
+// negate is -x, failing with ErrOverflow for the one int with no negative.
+func negate(x int) (int, error) {
+	if x == minInt {
+		return 0, ErrOverflow
+	}
+	return -x, nil
+}
+
+// apply works out left op right, failing with ErrOverflow rather than
+// wrapping around.
+func apply(op string, left, right int) (int, error) {
+	switch op {
+	case "+":
+		sum := left + right
+		if (right > 0 && sum < left) || (right < 0 && sum > left) {
+			return 0, ErrOverflow
+		}
+		return sum, nil
+	case "-":
+		difference := left - right
+		if (right > 0 && difference > left) || (right < 0 && difference < left) {
+			return 0, ErrOverflow
+		}
+		return difference, nil
+	case "*":
+		if left == 0 || right == 0 {
+			return 0, nil
+		}
+		product := left * right
+		if product/right != left || (left == -1 && right == minInt) || (right == -1 && left == minInt) {
+			return 0, ErrOverflow
+		}
+		return product, nil
+	case "/", "%":
+		if right == 0 {
+			return 0, ErrDivisionByZero
+		}
+		if right == -1 && left == minInt {
+			if op == "%" {
+				return 0, nil
+			}
+			return 0, ErrOverflow
+		}
+		if op == "/" {
+			return left / right, nil
+		}
+		return left % right, nil
+	default:
+		return 0, fmt.Errorf("unknown operator %q", op)
+	}
+}
+
+// Fold works out every part of the tree that doesn't depend on a variable
+// ahead of time. Divisions by a constant zero and anything that overflows
+// are left for Eval to report.
+func Fold(n Node) Node {
+	switch n := n.(type) {
+	case *Negate:
+		x := Fold(n.X)
+		if num, isNum := x.(*Num); isNum {
+			if value, err := negate(num.Value); err == nil {
+				return &Num{Value: value}
+			}
+		}
+		return &Negate{X: x, Pos: n.Pos}
+	case *Binary:
+		left, right := Fold(n.Left), Fold(n.Right)
+		leftNum, leftIsNum := left.(*Num)
+		rightNum, rightIsNum := right.(*Num)
+		if leftIsNum && rightIsNum {
+			if value, err := apply(n.Op, leftNum.Value, rightNum.Value); err == nil {
+				return &Num{Value: value}
+			}
+		}
+		return &Binary{Op: n.Op, Left: left, Right: right, Pos: n.Pos}
+	default:
+		return n
+	}
+}
+
+// Vars lists the variables n refers to, each once, in the order they first
+// appear.
+func Vars(n Node) []*Var {
+	vars := []*Var{}
+	seen := map[string]bool{}
+
+	var walk func(n Node)
+	walk = func(n Node) {
+		switch n := n.(type) {
+		case *Var:
+			if !seen[n.Name] {
+				seen[n.Name] = true
+				vars = append(vars, n)
+			}
+		case *Negate:
+			walk(n.X)
+		case *Binary:
+			walk(n.Left)
+			walk(n.Right)
+		}
+	}
+	walk(n)
+
+	return vars
+}
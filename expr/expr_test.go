@@ -0,0 +1,146 @@
+package expr
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"1 + 2 * 3", "(1 + (2 * 3))"},
+		{"(1 + 2) * 3", "((1 + 2) * 3)"},
+		{"1 - 2 - 3", "((1 - 2) - 3)"},
+		{"8 / 4 / 2", "((8 / 4) / 2)"},
+		{"7 % 4 * 2", "((7 % 4) * 2)"},
+		{"-old * -3", "((-old) * (-3))"},
+		{"--x", "(-(-x))"},
+		{"old * (old + 3)", "(old * (old + 3))"},
+		{"a+b*c-d/e", "((a + (b * c)) - (d / e))"},
+	}
+	for _, test := range tests {
+		node, err := Parse(test.src)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", test.src, err)
+			continue
+		}
+		if got := node.String(); got != test.want {
+			t.Errorf("Parse(%q) = %s, want %s", test.src, got, test.want)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	env := Env{"old": 7, "x": -2}
+	tests := []struct {
+		src  string
+		want int
+	}{
+		{"1 + 2 * 3", 7},
+		{"1 - 2 - 3", -4},
+		{"8 / 4 / 2", 1},
+		{"-7 / 2", -3},
+		{"-7 % 3", -1},
+		{"old * (old + 3)", 70},
+		{"-x", 2},
+		{"old * old - x", 51},
+	}
+	for _, test := range tests {
+		node, err := Parse(test.src)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", test.src, err)
+			continue
+		}
+		if got, err := node.Eval(env); err != nil || got != test.want {
+			t.Errorf("%s = %d, %v, want %d", test.src, got, err, test.want)
+		}
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		src string
+		pos int
+	}{
+		{"1 + #", 4},
+		{"1 +", 3},
+		{"(1 + 2", 6},
+		{"1 2", 2},
+		{")", 0},
+		{"", 0},
+		{"99999999999999999999", 0},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.src)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Parse(%q) error = %v, want a *SyntaxError", test.src, err)
+			continue
+		}
+		if syntaxErr.Pos != test.pos {
+			t.Errorf("Parse(%q) error at %d, want %d: %v", test.src, syntaxErr.Pos, test.pos, err)
+		}
+	}
+
+	_, err := Parse("99999999999999999999")
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("out of range number error = %v, want strconv.ErrRange", err)
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	tests := []string{
+		"9223372036854775807 + 1",
+		"-9223372036854775807 - 2",
+		"4611686018427387904 * 2",
+		"-(-9223372036854775807 - 1)",
+		"(-9223372036854775807 - 1) / -1",
+		"(-9223372036854775807 - 1) * -1",
+		"x + 9223372036854775807",
+	}
+	env := Env{"x": 1}
+	for _, src := range tests {
+		parsed, err := Parse(src)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", src, err)
+		}
+		if _, err := parsed.Eval(env); !errors.Is(err, ErrOverflow) {
+			t.Errorf("Eval %s error = %v, want ErrOverflow", src, err)
+		}
+
+		// folding leaves what overflows for Eval to report
+		compiled, err := Compile(src)
+		if err != nil {
+			t.Fatalf("Compile(%q): %v", src, err)
+		}
+		if _, err := compiled.Eval(env); !errors.Is(err, ErrOverflow) {
+			t.Errorf("Eval of folded %s error = %v, want ErrOverflow", src, err)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"1 + 2 * 3", "7"},
+		{"old * (2 + 3)", "(old * 5)"},
+		{"-(2 * 3) + old", "(-6 + old)"},
+		{"old / (1 - 1)", "(old / 0)"},
+		{"-(-9223372036854775807 - 1)", "(--9223372036854775808)"},
+	}
+	for _, test := range tests {
+		node, err := Compile(test.src)
+		if err != nil {
+			t.Errorf("Compile(%q): %v", test.src, err)
+			continue
+		}
+		if got := node.String(); got != test.want {
+			t.Errorf("Compile(%q) = %s, want %s", test.src, got, test.want)
+		}
+	}
+}
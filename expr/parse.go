@@ -0,0 +1,119 @@
+package expr
+
+import "strconv"
+
+// precedence is how tightly each binary operator binds; all of them are left
+// associative.
+var precedence = map[string]int{
+	"+": 1,
+	"-": 1,
+	"*": 2,
+	"/": 2,
+	"%": 2,
+}
+
+type parser struct {
+	tokens []Token
+	pos    int
+}
+
+// Parse parses src into an AST, leaving it unfolded so it still reads like
+// the source. See Compile.
+func Parse(src string) (Node, error) {
+	tokens, err := Tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{tokens: tokens}
+	node, err := p.parseExpr(1)
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.Kind != EOF {
+		return nil, &SyntaxError{Pos: next.Pos, Expected: "an operator or the end of the expression"}
+	}
+	return node, nil
+}
+
+// Compile parses src and folds its constants.
+func Compile(src string) (Node, error) {
+	node, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return Fold(node), nil
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() Token {
+	t := p.tokens[p.pos]
+	if t.Kind != EOF {
+		p.pos++
+	}
+	return t
+}
+
+// parseExpr is precedence climbing: it parses operands joined by operators
+// binding at least as tightly as minPrecedence.
+func (p *parser) parseExpr(minPrecedence int) (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.peek()
+		prec, isBinary := precedence[op.Text]
+		if op.Kind != Operator || !isBinary || prec < minPrecedence {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseExpr(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op.Text, Left: left, Right: right, Pos: op.Pos}
+	}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if t := p.peek(); t.Kind == Operator && t.Text == "-" {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Negate{X: x, Pos: t.Pos}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	t := p.next()
+	switch t.Kind {
+	case Number:
+		value, err := strconv.Atoi(t.Text)
+		if err != nil {
+			return nil, &SyntaxError{Pos: t.Pos, Expected: "an integer", Err: err}
+		}
+		return &Num{Value: value}, nil
+	case Ident:
+		return &Var{Name: t.Text, Pos: t.Pos}, nil
+	case LeftParen:
+		inner, err := p.parseExpr(1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.Kind != RightParen {
+			return nil, &SyntaxError{Pos: closing.Pos, Expected: "`)`"}
+		}
+		return inner, nil
+	default:
+		return nil, &SyntaxError{Pos: t.Pos, Expected: "a number, variable or `(`"}
+	}
+}
@@ -0,0 +1,119 @@
+// Package expr parses and evaluates integer arithmetic like `old * (old + 3)`.
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Kind int
+
+const (
+	EOF Kind = iota
+	Number
+	Ident
+	Operator
+	LeftParen
+	RightParen
+)
+
+func (k Kind) String() string {
+	switch k {
+	case EOF:
+		return "end of expression"
+	case Number:
+		return "number"
+	case Ident:
+		return "variable"
+	case Operator:
+		return "operator"
+	case LeftParen:
+		return "`(`"
+	case RightParen:
+		return "`)`"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+type Token struct {
+	Kind Kind
+	Text string
+	// Pos is the byte offset of the token in the source.
+	Pos int
+}
+
+const operators = "+-*/%"
+
+// SyntaxError is where in the source an expression stopped making sense.
+type SyntaxError struct {
+	// Pos is a byte offset into the source.
+	Pos      int
+	Expected string
+	Err      error
+}
+
+func (e *SyntaxError) Error() string {
+	message := fmt.Sprintf("offset %d: expected %s", e.Pos, e.Expected)
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// Tokenize splits src into tokens, ending with an EOF token. Whitespace
+// only separates tokens.
+func Tokenize(src string) ([]Token, error) {
+	tokens := []Token{}
+
+	pos := 0
+	// span advances pos past the run of runes matching keep.
+	span := func(keep func(rune) bool) {
+		for pos < len(src) {
+			r, size := utf8.DecodeRuneInString(src[pos:])
+			if !keep(r) {
+				return
+			}
+			pos += size
+		}
+	}
+	isIdent := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+	}
+
+	for pos < len(src) {
+		r, size := utf8.DecodeRuneInString(src[pos:])
+		start := pos
+		switch {
+		case unicode.IsSpace(r):
+			span(unicode.IsSpace)
+			continue
+		case unicode.IsDigit(r):
+			span(unicode.IsDigit)
+			tokens = append(tokens, Token{Number, src[start:pos], start})
+		case unicode.IsLetter(r) || r == '_':
+			span(isIdent)
+			tokens = append(tokens, Token{Ident, src[start:pos], start})
+		case r == '(':
+			pos += size
+			tokens = append(tokens, Token{LeftParen, "(", start})
+		case r == ')':
+			pos += size
+			tokens = append(tokens, Token{RightParen, ")", start})
+		case strings.ContainsRune(operators, r):
+			pos += size
+			tokens = append(tokens, Token{Operator, string(r), start})
+		default:
+			return nil, &SyntaxError{Pos: start, Expected: "a number, variable, operator or parenthesis"}
+		}
+	}
+	tokens = append(tokens, Token{EOF, "", len(src)})
+
+	return tokens, nil
+}
@@ -0,0 +1,3 @@
+module github.com/Takadimi/aoc
+
+go 1.21
@@ -0,0 +1,88 @@
+package graph
+
+// Bridges lists the edges of an undirected graph whose removal would split
+// a component in two.
+func (g *Graph[N]) Bridges() [][2]N {
+	bridges := [][2]N{}
+	g.lowLinks(func(v, w int) {
+		bridges = append(bridges, [2]N{g.nodes[v], g.nodes[w]})
+	}, nil)
+	return bridges
+}
+
+// ArticulationPoints lists the nodes of an undirected graph whose removal
+// would split a component in two.
+func (g *Graph[N]) ArticulationPoints() []N {
+	isPoint := make([]bool, len(g.nodes))
+	g.lowLinks(nil, func(v int) {
+		isPoint[v] = true
+	})
+
+	points := []N{}
+	for v, n := range g.nodes {
+		if isPoint[v] {
+			points = append(points, n)
+		}
+	}
+	return points
+}
+
+// lowLinks does Tarjan's depth first search for bridges and articulation
+// points, reporting each to the callbacks that aren't nil. Articulation
+// points can be reported more than once.
+func (g *Graph[N]) lowLinks(bridge func(v, w int), articulationPoint func(v int)) {
+	if g.directed {
+		panic("graph: bridges and articulation points of a directed graph")
+	}
+
+	const unvisited = -1
+	index := make([]int, len(g.nodes))
+	low := make([]int, len(g.nodes))
+	for i := range index {
+		index[i] = unvisited
+	}
+	next := 0
+
+	var visit func(v, parent int)
+	visit = func(v, parent int) {
+		index[v], low[v] = next, next
+		next++
+
+		children := 0
+		skippedParent := false
+		for _, w := range g.edges[v] {
+			// only the one edge back to the parent is the tree edge; any
+			// parallel ones are real ways back
+			if w == parent && !skippedParent {
+				skippedParent = true
+				continue
+			}
+			if index[w] != unvisited {
+				low[v] = minInt(low[v], index[w])
+				continue
+			}
+
+			children++
+			visit(w, v)
+			low[v] = minInt(low[v], low[w])
+
+			if low[w] > index[v] && bridge != nil {
+				bridge(v, w)
+			}
+			if parent != unvisited && low[w] >= index[v] && articulationPoint != nil {
+				articulationPoint(v)
+			}
+		}
+
+		// a root is only an articulation point if it holds subtrees apart
+		if parent == unvisited && children > 1 && articulationPoint != nil {
+			articulationPoint(v)
+		}
+	}
+
+	for v := range g.nodes {
+		if index[v] == unvisited {
+			visit(v, unvisited)
+		}
+	}
+}
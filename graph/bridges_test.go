@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// cycleWithTail is the triangle A B C with a tail C D E hanging off it.
+func cycleWithTail() *Graph[string] {
+	g := NewUndirected[string]()
+	g.AddEdge("A", "B")
+	g.AddEdge("B", "C")
+	g.AddEdge("C", "A")
+	g.AddEdge("C", "D")
+	g.AddEdge("D", "E")
+	return g
+}
+
+// sortedBridges puts each bridge's ends and then the bridges in order.
+func sortedBridges(bridges [][2]string) [][2]string {
+	out := [][2]string{}
+	for _, b := range bridges {
+		if b[0] > b[1] {
+			b[0], b[1] = b[1], b[0]
+		}
+		out = append(out, b)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i][0] < out[j][0] || (out[i][0] == out[j][0] && out[i][1] < out[j][1])
+	})
+	return out
+}
+
+func TestBridges(t *testing.T) {
+	want := [][2]string{{"C", "D"}, {"D", "E"}}
+	if got := sortedBridges(cycleWithTail().Bridges()); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// a second edge between two nodes is another way across
+	g := NewUndirected[string]()
+	g.AddEdge("A", "B")
+	g.AddEdge("A", "B")
+	g.AddEdge("B", "C")
+	want = [][2]string{{"B", "C"}}
+	if got := sortedBridges(g.Bridges()); !reflect.DeepEqual(got, want) {
+		t.Errorf("with a parallel edge got %v, want %v", got, want)
+	}
+}
+
+func TestArticulationPoints(t *testing.T) {
+	want := []string{"C", "D"}
+	if got := cycleWithTail().ArticulationPoints(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// a root with two subtrees holds them apart, but not with one
+	star := NewUndirected[string]()
+	star.AddEdge("hub", "A")
+	star.AddEdge("hub", "B")
+	if got := star.ArticulationPoints(); !reflect.DeepEqual(got, []string{"hub"}) {
+		t.Errorf("star got %v, want [hub]", got)
+	}
+	line := NewUndirected[string]()
+	line.AddEdge("A", "B")
+	if got := line.ArticulationPoints(); len(got) != 0 {
+		t.Errorf("single edge got %v, want none", got)
+	}
+}
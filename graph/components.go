@@ -0,0 +1,91 @@
+package graph
+
+// Components splits the graph into connected components, ignoring which way
+// edges point. Components are ordered by their first node, and nodes within
+// them by the order they were added.
+func (g *Graph[N]) Components() [][]N {
+	d := NewDSU(len(g.nodes))
+	for from, edges := range g.edges {
+		for _, to := range edges {
+			d.Union(from, to)
+		}
+	}
+
+	componentByRoot := map[int]int{}
+	components := [][]N{}
+	for id, n := range g.nodes {
+		root := d.Find(id)
+		c, isSeen := componentByRoot[root]
+		if !isSeen {
+			c = len(components)
+			componentByRoot[root] = c
+			components = append(components, nil)
+		}
+		components[c] = append(components[c], n)
+	}
+	return components
+}
+
+// StronglyConnectedComponents finds the sets of nodes that can all reach one
+// another, using Tarjan's algorithm. Components come out in reverse
+// topological order: no component has an edge to one listed after it.
+func (g *Graph[N]) StronglyConnectedComponents() [][]N {
+	const unvisited = -1
+
+	index := make([]int, len(g.nodes))
+	low := make([]int, len(g.nodes))
+	onStack := make([]bool, len(g.nodes))
+	for i := range index {
+		index[i] = unvisited
+	}
+	stack := []int{}
+	next := 0
+	components := [][]N{}
+
+	var connect func(v int)
+	connect = func(v int) {
+		index[v], low[v] = next, next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range g.edges[v] {
+			if index[w] == unvisited {
+				connect(w)
+				low[v] = minInt(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = minInt(low[v], index[w])
+			}
+		}
+
+		// v is the root of a component, which is everything above it on
+		// the stack
+		if low[v] == index[v] {
+			component := []int{}
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			components = append(components, g.lookup(component))
+		}
+	}
+
+	for v := range g.nodes {
+		if index[v] == unvisited {
+			connect(v)
+		}
+	}
+	return components
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,69 @@
+package graph
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// sorted sorts each component so tests don't depend on the order nodes
+// come out of one in.
+func sorted(components [][]string) [][]string {
+	out := [][]string{}
+	for _, c := range components {
+		c = append([]string(nil), c...)
+		sort.Strings(c)
+		out = append(out, c)
+	}
+	return out
+}
+
+func TestComponents(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("A", "B")
+	g.AddEdge("C", "B")
+	g.AddEdge("D", "E")
+	g.AddNode("F")
+	g.AddEdge("E", "E")
+
+	want := [][]string{{"A", "B", "C"}, {"D", "E"}, {"F"}}
+	if got := g.Components(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := NewDirected[string]()
+	// a cycle leading on to a node with a self loop and then a dead end,
+	// plus a node of its own
+	g.AddEdge("A", "B")
+	g.AddEdge("B", "C")
+	g.AddEdge("C", "A")
+	g.AddEdge("C", "D")
+	g.AddEdge("D", "D")
+	g.AddEdge("D", "F")
+	g.AddNode("E")
+	// G and H reach each other and the cycle, but not back
+	g.AddEdge("G", "H")
+	g.AddEdge("H", "G")
+	g.AddEdge("H", "B")
+
+	// reverse topological order, every component only having edges to the
+	// ones before it
+	want := [][]string{{"F"}, {"D"}, {"A", "B", "C"}, {"E"}, {"G", "H"}}
+	if got := sorted(g.StronglyConnectedComponents()); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestStronglyConnectedComponentsSelfLoops(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("A", "A")
+	g.AddEdge("A", "B")
+	g.AddEdge("B", "B")
+
+	want := [][]string{{"B"}, {"A"}}
+	if got := g.StronglyConnectedComponents(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+package graph
+
+// DSU is a disjoint-set union (union-find) over the elements 0 to n-1. With
+// path compression and union by rank, operations take near constant time.
+type DSU struct {
+	parent []int
+	rank   []int
+	sets   int
+}
+
+// NewDSU starts every element off in a set of its own.
+func NewDSU(n int) *DSU {
+	d := &DSU{parent: make([]int, n), rank: make([]int, n), sets: n}
+	for i := range d.parent {
+		d.parent[i] = i
+	}
+	return d
+}
+
+// Find is the representative of the set holding x.
+func (d *DSU) Find(x int) int {
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	// point everything on the way straight at the root
+	for d.parent[x] != root {
+		d.parent[x], x = root, d.parent[x]
+	}
+	return root
+}
+
+// Union merges the sets holding a and b, reporting false if they were
+// already the same set.
+func (d *DSU) Union(a, b int) bool {
+	a, b = d.Find(a), d.Find(b)
+	if a == b {
+		return false
+	}
+
+	// hang the shallower tree off the deeper one
+	if d.rank[a] < d.rank[b] {
+		a, b = b, a
+	}
+	d.parent[b] = a
+	if d.rank[a] == d.rank[b] {
+		d.rank[a]++
+	}
+	d.sets--
+	return true
+}
+
+func (d *DSU) Same(a, b int) bool {
+	return d.Find(a) == d.Find(b)
+}
+
+// Sets is the number of disjoint sets left.
+func (d *DSU) Sets() int {
+	return d.sets
+}
@@ -0,0 +1,40 @@
+package graph
+
+import "testing"
+
+func TestDSU(t *testing.T) {
+	d := NewDSU(6)
+	if d.Sets() != 6 {
+		t.Fatalf("started with %d sets, want 6", d.Sets())
+	}
+
+	for _, pair := range [][2]int{{0, 1}, {2, 3}, {1, 3}} {
+		if !d.Union(pair[0], pair[1]) {
+			t.Errorf("Union(%d, %d) reported them already joined", pair[0], pair[1])
+		}
+	}
+	if d.Union(0, 2) {
+		t.Error("Union(0, 2) joined sets that were already one")
+	}
+	if d.Sets() != 3 {
+		t.Errorf("%d sets left, want 3", d.Sets())
+	}
+
+	for _, pair := range [][2]int{{0, 3}, {1, 2}, {4, 4}} {
+		if !d.Same(pair[0], pair[1]) {
+			t.Errorf("%d and %d aren't in the same set", pair[0], pair[1])
+		}
+	}
+	for _, pair := range [][2]int{{0, 4}, {4, 5}} {
+		if d.Same(pair[0], pair[1]) {
+			t.Errorf("%d and %d are in the same set", pair[0], pair[1])
+		}
+	}
+
+	root := d.Find(0)
+	for _, x := range []int{1, 2, 3} {
+		if d.Find(x) != root {
+			t.Errorf("Find(%d) = %d, want %d like Find(0)", x, d.Find(x), root)
+		}
+	}
+}
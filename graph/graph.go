@@ -0,0 +1,108 @@
+// Package graph holds a small adjacency list graph and the usual
+// connectivity algorithms over it.
+package graph
+
+// Graph is a directed or undirected graph of comparable nodes. Nodes keep
+// the order they were added in, so everything built from a graph comes out
+// the same way on every run.
+type Graph[N comparable] struct {
+	directed bool
+	nodes    []N
+	index    map[N]int
+	edges    [][]int
+}
+
+func NewDirected[N comparable]() *Graph[N] {
+	return &Graph[N]{directed: true, index: make(map[N]int)}
+}
+
+func NewUndirected[N comparable]() *Graph[N] {
+	return &Graph[N]{index: make(map[N]int)}
+}
+
+func (g *Graph[N]) Directed() bool {
+	return g.directed
+}
+
+// AddNode adds n if it isn't in the graph already.
+func (g *Graph[N]) AddNode(n N) {
+	g.id(n)
+}
+
+func (g *Graph[N]) id(n N) int {
+	if i, isAdded := g.index[n]; isAdded {
+		return i
+	}
+	g.index[n] = len(g.nodes)
+	g.nodes = append(g.nodes, n)
+	g.edges = append(g.edges, nil)
+	return len(g.nodes) - 1
+}
+
+// AddEdge adds an edge from a to b, and back again if the graph is
+// undirected, adding either node that's missing.
+func (g *Graph[N]) AddEdge(a, b N) {
+	i, j := g.id(a), g.id(b)
+	g.edges[i] = append(g.edges[i], j)
+	if !g.directed && i != j {
+		g.edges[j] = append(g.edges[j], i)
+	}
+}
+
+func (g *Graph[N]) Has(n N) bool {
+	_, isAdded := g.index[n]
+	return isAdded
+}
+
+func (g *Graph[N]) Nodes() []N {
+	return append([]N(nil), g.nodes...)
+}
+
+func (g *Graph[N]) Neighbours(n N) []N {
+	i, isAdded := g.index[n]
+	if !isAdded {
+		return nil
+	}
+	return g.lookup(g.edges[i])
+}
+
+// lookup turns node ids back into nodes.
+func (g *Graph[N]) lookup(ids []int) []N {
+	nodes := make([]N, 0, len(ids))
+	for _, id := range ids {
+		nodes = append(nodes, g.nodes[id])
+	}
+	return nodes
+}
+
+// Reachable lists every node that can be reached from `from`, itself
+// included, breadth first.
+func (g *Graph[N]) Reachable(from N) []N {
+	start, isAdded := g.index[from]
+	if !isAdded {
+		return nil
+	}
+
+	seen := make([]bool, len(g.nodes))
+	seen[start] = true
+	queue := []int{start}
+	for i := 0; i < len(queue); i++ {
+		for _, next := range g.edges[queue[i]] {
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return g.lookup(queue)
+}
+
+// PathExists reports whether `to` can be reached from `from`.
+func (g *Graph[N]) PathExists(from, to N) bool {
+	for _, n := range g.Reachable(from) {
+		if n == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CycleError is why a graph has no topological order: a cycle of nodes,
+// each with an edge to the next and the last back to the first.
+type CycleError[N comparable] struct {
+	Cycle []N
+}
+
+func (e *CycleError[N]) Error() string {
+	nodes := []string{}
+	for _, n := range append(e.Cycle, e.Cycle[0]) {
+		nodes = append(nodes, fmt.Sprint(n))
+	}
+	return "graph: cycle " + strings.Join(nodes, " -> ")
+}
+
+// TopologicalSort orders the nodes of a directed graph so every edge points
+// forwards, preferring the order nodes were added in where there's a choice.
+// A graph with a cycle has no such order, and a *CycleError reports one.
+func (g *Graph[N]) TopologicalSort() ([]N, error) {
+	if !g.directed {
+		panic("graph: topological sort of an undirected graph")
+	}
+
+	inDegree := make([]int, len(g.nodes))
+	for _, edges := range g.edges {
+		for _, to := range edges {
+			inDegree[to]++
+		}
+	}
+
+	// Kahn's algorithm: repeatedly take a node nothing left points to
+	order := []int{}
+	for v, degree := range inDegree {
+		if degree == 0 {
+			order = append(order, v)
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		for _, to := range g.edges[order[i]] {
+			inDegree[to]--
+			if inDegree[to] == 0 {
+				order = append(order, to)
+			}
+		}
+	}
+
+	if len(order) < len(g.nodes) {
+		return nil, &CycleError[N]{Cycle: g.lookup(g.findCycle(inDegree))}
+	}
+	return g.lookup(order), nil
+}
+
+// findCycle walks backwards from any node Kahn's algorithm couldn't place.
+// What's left of such a node's in-degree counts edges from other unplaced
+// nodes, so it always has an unplaced predecessor to step back to, and the
+// walk must eventually come back round. Unplaced nodes needn't have an edge
+// on to another unplaced node, so walking forwards could dead end.
+func (g *Graph[N]) findCycle(inDegree []int) []int {
+	predecessor := make([]int, len(g.nodes))
+	for from, edges := range g.edges {
+		if inDegree[from] == 0 {
+			continue
+		}
+		for _, to := range edges {
+			predecessor[to] = from
+		}
+	}
+
+	start := 0
+	for inDegree[start] == 0 {
+		start++
+	}
+
+	position := map[int]int{}
+	path := []int{}
+	for v := start; ; v = predecessor[v] {
+		if at, isOnPath := position[v]; isOnPath {
+			// the path runs against the edges, so the cycle is it reversed
+			cycle := append([]int(nil), path[at:]...)
+			for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+				cycle[i], cycle[j] = cycle[j], cycle[i]
+			}
+			return cycle
+		}
+		position[v] = len(path)
+		path = append(path, v)
+	}
+}
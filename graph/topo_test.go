@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTopologicalSort(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("shirt", "tie")
+	g.AddEdge("tie", "jacket")
+	g.AddEdge("trousers", "shoes")
+	g.AddEdge("trousers", "jacket")
+
+	order, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"shirt", "trousers", "tie", "shoes", "jacket"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("got %v, want %v", order, want)
+	}
+}
+
+func TestTopologicalSortCycle(t *testing.T) {
+	for name, build := range map[string]func(g *Graph[string]){
+		"self loop": func(g *Graph[string]) {
+			g.AddEdge("A", "A")
+		},
+		// C can't be placed but leads nowhere, so only walking back from it
+		// finds the cycle
+		"dead end after cycle": func(g *Graph[string]) {
+			g.AddNode("C")
+			g.AddEdge("A", "B")
+			g.AddEdge("B", "A")
+			g.AddEdge("B", "C")
+		},
+		"cycle after path": func(g *Graph[string]) {
+			g.AddEdge("start", "A")
+			g.AddEdge("A", "B")
+			g.AddEdge("B", "C")
+			g.AddEdge("C", "A")
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := NewDirected[string]()
+			build(g)
+
+			_, err := g.TopologicalSort()
+			var cycleErr *CycleError[string]
+			if !errors.As(err, &cycleErr) {
+				t.Fatalf("got %v, want a *CycleError", err)
+			}
+			cycle := cycleErr.Cycle
+			for i, from := range cycle {
+				to := cycle[(i+1)%len(cycle)]
+				if !g.hasEdge(from, to) {
+					t.Errorf("%v: no edge %s -> %s", err, from, to)
+				}
+			}
+		})
+	}
+}
+
+func (g *Graph[N]) hasEdge(from, to N) bool {
+	for _, n := range g.Neighbours(from) {
+		if n == to {
+			return true
+		}
+	}
+	return false
+}
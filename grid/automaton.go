@@ -0,0 +1,98 @@
+package grid
+
+import "context"
+
+// RuleGroup updates every cell of the grid at once from its neighbourhood.
+type RuleGroup[T comparable] struct {
+	Neighbourhood Neighbourhood
+	// Rule is the cell's next value given its current one and its
+	// neighbours' values, in the order of the neighbourhood. The neighbours
+	// slice is reused, so rules mustn't hold on to it.
+	Rule func(cell T, neighbours []T) T
+}
+
+// Automaton is a cellular automaton. Each step runs its rule groups in
+// order, every group seeing the grid as the group before left it, so a
+// single group is a plain synchronous update and several make a phased one.
+// Groups write into a second buffer that's swapped in afterwards, so steps
+// don't allocate.
+type Automaton[T comparable] struct {
+	Groups []RuleGroup[T]
+	Edges  Edges
+	// Outside is the value of neighbours off the edge of a bounded grid.
+	Outside T
+	// Steps counts the steps taken so far.
+	Steps int
+
+	current, next *Grid[T]
+	neighbours    []T
+}
+
+// NewAutomaton runs groups over a copy of g.
+func NewAutomaton[T comparable](g *Grid[T], edges Edges, groups ...RuleGroup[T]) *Automaton[T] {
+	return &Automaton[T]{
+		Groups:  groups,
+		Edges:   edges,
+		current: g.Clone(),
+		next:    New[T](g.Width, g.Height),
+	}
+}
+
+// Grid is the grid as of the last step. It's only valid until the next one.
+func (a *Automaton[T]) Grid() *Grid[T] {
+	return a.current
+}
+
+// Step runs every rule group once, returning how many cell updates changed
+// a cell's value.
+func (a *Automaton[T]) Step() int {
+	changed := 0
+	for _, group := range a.Groups {
+		changed += a.apply(group)
+	}
+	a.Steps++
+	return changed
+}
+
+func (a *Automaton[T]) apply(group RuleGroup[T]) int {
+	changed := 0
+	for y := 0; y < a.current.Height; y++ {
+		for x := 0; x < a.current.Width; x++ {
+			p := Point{x, y}
+
+			a.neighbours = a.neighbours[:0]
+			for _, offset := range group.Neighbourhood {
+				value := a.Outside
+				if n, isIn := a.current.Move(p, offset, a.Edges); isIn {
+					value = a.current.Get(n)
+				}
+				a.neighbours = append(a.neighbours, value)
+			}
+
+			cell := a.current.Get(p)
+			next := group.Rule(cell, a.neighbours)
+			if next != cell {
+				changed++
+			}
+			a.next.Set(p, next)
+		}
+	}
+
+	a.current, a.next = a.next, a.current
+	return changed
+}
+
+// RunUntilStable steps until a step changes nothing, returning the number of
+// that step. It gives up after limit steps, or never when limit is negative,
+// returning false.
+func (a *Automaton[T]) RunUntilStable(ctx context.Context, limit int) (int, bool, error) {
+	for n := 0; limit < 0 || n < limit; n++ {
+		if err := ctx.Err(); err != nil {
+			return a.Steps, false, err
+		}
+		if a.Step() == 0 {
+			return a.Steps, true, nil
+		}
+	}
+	return a.Steps, false, nil
+}
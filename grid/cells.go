@@ -0,0 +1,80 @@
+package grid
+
+import "sort"
+
+// Cells is anything cells can be looked up in by position, so the same
+// algorithms run over a dense Grid, plain Rows or a Sparse map.
+type Cells[T any] interface {
+	// Lookup is the cell at p, or false if there's no cell there.
+	Lookup(p Point) (T, bool)
+	// Points lists every cell's position, row by row.
+	Points() []Point
+}
+
+func (g *Grid[T]) Lookup(p Point) (T, bool) {
+	if !g.In(p) {
+		var none T
+		return none, false
+	}
+	return g.Get(p), true
+}
+
+func (g *Grid[T]) Points() []Point {
+	points := make([]Point, 0, len(g.Cells))
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			points = append(points, Point{x, y})
+		}
+	}
+	return points
+}
+
+// Rows is a grid kept as a slice of rows, indexed [y][x]. Rows needn't all
+// be the same length.
+type Rows[T any] [][]T
+
+func (r Rows[T]) Lookup(p Point) (T, bool) {
+	if p.Y < 0 || p.Y >= len(r) || p.X < 0 || p.X >= len(r[p.Y]) {
+		var none T
+		return none, false
+	}
+	return r[p.Y][p.X], true
+}
+
+func (r Rows[T]) Points() []Point {
+	points := []Point{}
+	for y, row := range r {
+		for x := range row {
+			points = append(points, Point{x, y})
+		}
+	}
+	return points
+}
+
+// Sparse is a grid holding only the cells that have been set, for grids
+// that are mostly empty or have no fixed bounds.
+type Sparse[T any] map[Point]T
+
+func (s Sparse[T]) Lookup(p Point) (T, bool) {
+	value, isSet := s[p]
+	return value, isSet
+}
+
+func (s Sparse[T]) Points() []Point {
+	points := make([]Point, 0, len(s))
+	for p := range s {
+		points = append(points, p)
+	}
+	sortPoints(points)
+	return points
+}
+
+// sortPoints puts points in reading order, row by row.
+func sortPoints(points []Point) {
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Y != points[j].Y {
+			return points[i].Y < points[j].Y
+		}
+		return points[i].X < points[j].X
+	})
+}
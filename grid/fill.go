@@ -0,0 +1,191 @@
+package grid
+
+import "github.com/Takadimi/aoc/set"
+
+// Order is which cells a fill visits first.
+type Order int
+
+const (
+	// BreadthFirst visits cells nearest the start first.
+	BreadthFirst Order = iota
+	// DepthFirst follows each way as far as it goes before backing up.
+	DepthFirst
+)
+
+// Fill finds the cells reachable from start by stepping between neighbours
+// that are inside, in the order they're visited. Nothing is reachable from
+// a start that isn't itself inside.
+func Fill[T any](cells Cells[T], start Point, n Neighbourhood, order Order, inside func(p Point, value T) bool) []Point {
+	value, isCell := cells.Lookup(start)
+	if !isCell || !inside(start, value) {
+		return nil
+	}
+	return fill(cells, []Point{start}, n, order, inside)
+}
+
+// fill floods out from every starting point at once. The starts must all be
+// inside.
+func fill[T any](cells Cells[T], starts []Point, n Neighbourhood, order Order, inside func(p Point, value T) bool) []Point {
+	seen := map[Point]bool{}
+	for _, p := range starts {
+		seen[p] = true
+	}
+
+	visited := []Point{}
+	pending := append([]Point(nil), starts...)
+	for len(pending) > 0 {
+		var p Point
+		if order == DepthFirst {
+			p, pending = pending[len(pending)-1], pending[:len(pending)-1]
+		} else {
+			p, pending = pending[0], pending[1:]
+		}
+		visited = append(visited, p)
+
+		for _, offset := range n {
+			next := p.Add(offset)
+			if seen[next] {
+				continue
+			}
+			value, isCell := cells.Lookup(next)
+			if isCell && inside(next, value) {
+				seen[next] = true
+				pending = append(pending, next)
+			}
+		}
+	}
+	return visited
+}
+
+// Region is a set of connected cells that belong together.
+type Region struct {
+	Label  int
+	Points []Point
+	// Perimeter is how many cell sides lie between the region and what's
+	// around it, the edge of the grid included.
+	Perimeter int
+}
+
+func (r Region) Size() int {
+	return len(r.Points)
+}
+
+// Label splits the cells into regions, each made of neighbouring cells that
+// same says belong together. It returns every cell's region label alongside
+// the regions, which are labelled from 0 in reading order.
+func Label[T any](cells Cells[T], n Neighbourhood, same func(a, b T) bool) (map[Point]int, []Region) {
+	labels := map[Point]int{}
+	regions := []Region{}
+	for _, start := range cells.Points() {
+		if _, isLabelled := labels[start]; isLabelled {
+			continue
+		}
+
+		first, _ := cells.Lookup(start)
+		region := Region{Label: len(regions)}
+		region.Points = Fill(cells, start, n, BreadthFirst, func(p Point, value T) bool {
+			_, isLabelled := labels[p]
+			return !isLabelled && same(first, value)
+		})
+		for _, p := range region.Points {
+			labels[p] = region.Label
+		}
+		regions = append(regions, region)
+	}
+
+	// perimeters are counted once every region's labelled, so sides on to
+	// another region count as well as sides on to the edge
+	for i := range regions {
+		for _, p := range regions[i].Points {
+			for _, offset := range VonNeumann {
+				if label, isLabelled := labels[p.Add(offset)]; !isLabelled || label != regions[i].Label {
+					regions[i].Perimeter++
+				}
+			}
+		}
+	}
+
+	return labels, regions
+}
+
+// Boundary is the cells of a region that are orthogonally next to the
+// outside of it. Cells only bordering holes inside the region aren't part
+// of its outer boundary.
+func Boundary(region []Point) []Point {
+	if len(region) == 0 {
+		return nil
+	}
+
+	inRegion := map[Point]bool{}
+	lo, hi := region[0], region[0]
+	for _, p := range region {
+		inRegion[p] = true
+		lo.X, lo.Y = minInt(lo.X, p.X), minInt(lo.Y, p.Y)
+		hi.X, hi.Y = maxInt(hi.X, p.X), maxInt(hi.Y, p.Y)
+	}
+
+	// flood the space around the region from just outside its corner; a
+	// margin of one cell lets the flood get all the way round
+	box := Rows[bool]{}
+	for y := lo.Y - 1; y <= hi.Y+1; y++ {
+		box = append(box, make([]bool, hi.X-lo.X+3))
+	}
+	origin := Point{lo.X - 1, lo.Y - 1}
+	outside := set.NewSet[Point]()
+	for _, p := range Fill[bool](box, Point{}, VonNeumann, BreadthFirst, func(p Point, _ bool) bool {
+		return !inRegion[p.Add(origin)]
+	}) {
+		outside.Set(p.Add(origin))
+	}
+
+	boundary := []Point{}
+	for _, p := range region {
+		for _, offset := range VonNeumann {
+			if outside.Has(p.Add(offset)) {
+				boundary = append(boundary, p)
+				break
+			}
+		}
+	}
+	return boundary
+}
+
+// ReachableFromBorder finds the open cells that can be reached from the
+// border by stepping between open neighbours. Border cells are those with a
+// neighbour that isn't a cell at all, so the edge of a dense grid or the
+// fringe of a sparse one.
+func ReachableFromBorder[T any](cells Cells[T], n Neighbourhood, open func(p Point, value T) bool) *set.Set[Point] {
+	starts := []Point{}
+	for _, p := range cells.Points() {
+		value, _ := cells.Lookup(p)
+		if !open(p, value) {
+			continue
+		}
+		for _, offset := range n {
+			if _, isCell := cells.Lookup(p.Add(offset)); !isCell {
+				starts = append(starts, p)
+				break
+			}
+		}
+	}
+
+	reachable := set.NewSet[Point]()
+	for _, p := range fill(cells, starts, n, BreadthFirst, open) {
+		reachable.Set(p)
+	}
+	return reachable
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
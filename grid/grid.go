@@ -0,0 +1,135 @@
+// Package grid holds the two dimensional grids so many puzzles are drawn on.
+package grid
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Takadimi/aoc/input"
+)
+
+// Point is a cell position. Y grows downwards, like the lines of an input.
+type Point struct {
+	X, Y int
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+var (
+	North = Point{0, -1}
+	East  = Point{1, 0}
+	South = Point{0, 1}
+	West  = Point{-1, 0}
+)
+
+// Neighbourhood is the offsets of the cells around a cell that matter to it.
+type Neighbourhood []Point
+
+var (
+	// VonNeumann is the four orthogonally adjacent cells.
+	VonNeumann = Neighbourhood{North, East, South, West}
+	// Moore is all eight surrounding cells, diagonals included.
+	Moore = Neighbourhood{North, {1, -1}, East, {1, 1}, South, {-1, 1}, West, {-1, -1}}
+)
+
+// Edges decides what lies past the edge of a grid.
+type Edges int
+
+const (
+	// Bounded grids have nothing past their edges.
+	Bounded Edges = iota
+	// Toroidal grids wrap around, so leaving one edge enters the opposite.
+	Toroidal
+)
+
+// Grid is a dense, rectangular grid stored row by row.
+type Grid[T any] struct {
+	Width, Height int
+	Cells         []T
+}
+
+func New[T any](width, height int) *Grid[T] {
+	return &Grid[T]{Width: width, Height: height, Cells: make([]T, width*height)}
+}
+
+// Parse builds a grid from lines of equal length, one cell per rune. cell
+// converts a rune, returning false for runes that aren't a valid cell, which
+// are reported as not being what's expected.
+func Parse[T any](lines []string, cell func(r rune) (T, bool), expected string) (*Grid[T], error) {
+	if len(lines) == 0 || lines[0] == "" {
+		return nil, input.Expected(0, 0, "a row of the grid")
+	}
+
+	width := len([]rune(lines[0]))
+	g := New[T](width, len(lines))
+	for y, l := range lines {
+		row := []rune(l)
+		if len(row) != width {
+			return nil, input.Expected(y, 0, fmt.Sprintf("%d cells like the first row", width))
+		}
+		for x, r := range row {
+			value, isValid := cell(r)
+			if !isValid {
+				return nil, input.Expected(y, len(string(row[:x]))+1, expected)
+			}
+			g.Set(Point{x, y}, value)
+		}
+	}
+
+	return g, nil
+}
+
+func (g *Grid[T]) In(p Point) bool {
+	return p.X >= 0 && p.X < g.Width && p.Y >= 0 && p.Y < g.Height
+}
+
+// Get is the cell at p, which must be in the grid.
+func (g *Grid[T]) Get(p Point) T {
+	return g.Cells[p.Y*g.Width+p.X]
+}
+
+// Set changes the cell at p, which must be in the grid.
+func (g *Grid[T]) Set(p Point, value T) {
+	g.Cells[p.Y*g.Width+p.X] = value
+}
+
+// Row is row y, sharing the grid's cells.
+func (g *Grid[T]) Row(y int) []T {
+	return g.Cells[y*g.Width : (y+1)*g.Width]
+}
+
+// Move steps from p by offset. Toroidal grids wrap around their edges while
+// bounded ones report false for a step off the grid.
+func (g *Grid[T]) Move(p, offset Point, edges Edges) (Point, bool) {
+	next := p.Add(offset)
+	if g.In(next) {
+		return next, true
+	}
+	if edges == Toroidal {
+		next.X = ((next.X % g.Width) + g.Width) % g.Width
+		next.Y = ((next.Y % g.Height) + g.Height) % g.Height
+		return next, true
+	}
+	return next, false
+}
+
+// Clone copies the grid and its cells.
+func (g *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{Width: g.Width, Height: g.Height, Cells: append([]T(nil), g.Cells...)}
+}
+
+// Format renders the grid a row per line using cell to draw each cell.
+func (g *Grid[T]) Format(cell func(T) string) string {
+	sb := strings.Builder{}
+	for y := 0; y < g.Height; y++ {
+		if y > 0 {
+			sb.WriteString("\n")
+		}
+		for _, value := range g.Row(y) {
+			sb.WriteString(cell(value))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,114 @@
+// Package grid3 is the three dimensional companion to grid, for puzzles
+// about voxels and points in space.
+package grid3
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Takadimi/aoc/input"
+)
+
+type Point3 struct {
+	X, Y, Z int
+}
+
+func (p Point3) Add(q Point3) Point3 {
+	return Point3{p.X + q.X, p.Y + q.Y, p.Z + q.Z}
+}
+
+func (p Point3) Sub(q Point3) Point3 {
+	return Point3{p.X - q.X, p.Y - q.Y, p.Z - q.Z}
+}
+
+func (p Point3) String() string {
+	return fmt.Sprintf("%d,%d,%d", p.X, p.Y, p.Z)
+}
+
+// Neighbourhood is the offsets of the points around a point that matter to
+// it.
+type Neighbourhood []Point3
+
+var (
+	// Faces is the six points sharing a face with a voxel.
+	Faces = Neighbourhood{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}}
+	// Around is all 26 surrounding points, edges and corners included.
+	Around = around()
+)
+
+func around() Neighbourhood {
+	n := Neighbourhood{}
+	for x := -1; x <= 1; x++ {
+		for y := -1; y <= 1; y++ {
+			for z := -1; z <= 1; z++ {
+				if x != 0 || y != 0 || z != 0 {
+					n = append(n, Point3{x, y, z})
+				}
+			}
+		}
+	}
+	return n
+}
+
+// Rotation turns points about the origin, keeping the axes lined up.
+type Rotation func(p Point3) Point3
+
+// Rotations is the 24 ways of turning space so the axes stay lined up,
+// starting with leaving it as it is. They're every way of pointing the X
+// axis along one of the six directions, times the four quarter turns about
+// it.
+var Rotations = rotations()
+
+func rotations() []Rotation {
+	// facings point the X axis each of the six ways
+	facings := []Rotation{
+		func(p Point3) Point3 { return p },
+		func(p Point3) Point3 { return Point3{-p.X, -p.Y, p.Z} },
+		func(p Point3) Point3 { return Point3{-p.Y, p.X, p.Z} },
+		func(p Point3) Point3 { return Point3{p.Y, -p.X, p.Z} },
+		func(p Point3) Point3 { return Point3{-p.Z, p.Y, p.X} },
+		func(p Point3) Point3 { return Point3{p.Z, p.Y, -p.X} },
+	}
+	// turns are quarter turns about the X axis
+	turns := []Rotation{
+		func(p Point3) Point3 { return p },
+		func(p Point3) Point3 { return Point3{p.X, -p.Z, p.Y} },
+		func(p Point3) Point3 { return Point3{p.X, -p.Y, -p.Z} },
+		func(p Point3) Point3 { return Point3{p.X, p.Z, -p.Y} },
+	}
+
+	all := []Rotation{}
+	for _, facing := range facings {
+		for _, turn := range turns {
+			facing, turn := facing, turn
+			all = append(all, func(p Point3) Point3 { return facing(turn(p)) })
+		}
+	}
+	return all
+}
+
+// ParsePoints reads a point from each line, written `x,y,z`.
+func ParsePoints(lines []string) ([]Point3, error) {
+	points := []Point3{}
+	for i, l := range lines {
+		if l == "" {
+			continue
+		}
+
+		parts := strings.Split(l, ",")
+		if len(parts) != 3 {
+			return nil, input.Expected(i, 0, "a point like `1,2,3`")
+		}
+		coordinates := [3]int{}
+		for n, part := range parts {
+			c, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return nil, input.ExpectedErr(i, input.SplitColumn(l, ",", n), "a whole number coordinate", err)
+			}
+			coordinates[n] = c
+		}
+		points = append(points, Point3{coordinates[0], coordinates[1], coordinates[2]})
+	}
+	return points, nil
+}
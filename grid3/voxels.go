@@ -0,0 +1,179 @@
+package grid3
+
+import "sort"
+
+// Voxels is a sparse set of filled unit cubes, each named by its lowest
+// corner.
+type Voxels struct {
+	filled map[Point3]struct{}
+}
+
+func NewVoxels(points ...Point3) *Voxels {
+	v := &Voxels{filled: make(map[Point3]struct{})}
+	for _, p := range points {
+		v.Set(p)
+	}
+	return v
+}
+
+// Parse reads a voxel from each line, written `x,y,z`.
+func Parse(lines []string) (*Voxels, error) {
+	points, err := ParsePoints(lines)
+	if err != nil {
+		return nil, err
+	}
+	return NewVoxels(points...), nil
+}
+
+func (v *Voxels) Set(p Point3) {
+	v.filled[p] = struct{}{}
+}
+
+func (v *Voxels) Delete(p Point3) {
+	delete(v.filled, p)
+}
+
+func (v *Voxels) Has(p Point3) bool {
+	_, isFilled := v.filled[p]
+	return isFilled
+}
+
+func (v *Voxels) Len() int {
+	return len(v.filled)
+}
+
+// Points lists the filled voxels, ordered by X then Y then Z.
+func (v *Voxels) Points() []Point3 {
+	points := make([]Point3, 0, len(v.filled))
+	for p := range v.filled {
+		points = append(points, p)
+	}
+	sort.Slice(points, func(i, j int) bool {
+		a, b := points[i], points[j]
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.Z < b.Z
+	})
+	return points
+}
+
+// Neighbours calls visit with each of p's neighbours that's filled,
+// stopping early if visit returns false.
+func (v *Voxels) Neighbours(p Point3, n Neighbourhood, visit func(q Point3) bool) {
+	for _, offset := range n {
+		q := p.Add(offset)
+		if v.Has(q) && !visit(q) {
+			return
+		}
+	}
+}
+
+// Box is the points from Min to Max, both included.
+type Box struct {
+	Min, Max Point3
+}
+
+func (b Box) Contains(p Point3) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+// Grow widens the box by n on every side.
+func (b Box) Grow(n int) Box {
+	return Box{
+		Min: b.Min.Sub(Point3{n, n, n}),
+		Max: b.Max.Add(Point3{n, n, n}),
+	}
+}
+
+// Bounds is the smallest box holding every filled voxel, or false if there
+// aren't any.
+func (v *Voxels) Bounds() (Box, bool) {
+	isFirst := true
+	b := Box{}
+	for p := range v.filled {
+		if isFirst {
+			b = Box{Min: p, Max: p}
+			isFirst = false
+			continue
+		}
+		b.Min = Point3{minInt(b.Min.X, p.X), minInt(b.Min.Y, p.Y), minInt(b.Min.Z, p.Z)}
+		b.Max = Point3{maxInt(b.Max.X, p.X), maxInt(b.Max.Y, p.Y), maxInt(b.Max.Z, p.Z)}
+	}
+	return b, !isFirst
+}
+
+// SurfaceArea counts the faces of filled voxels that aren't against another
+// filled voxel, air pockets inside included.
+func (v *Voxels) SurfaceArea() int {
+	area := 0
+	for p := range v.filled {
+		for _, offset := range Faces {
+			if !v.Has(p.Add(offset)) {
+				area++
+			}
+		}
+	}
+	return area
+}
+
+// Exterior finds the empty points that can be reached from outside the
+// voxels without passing through one, flooding face to face through the
+// bounding box grown by one so the flood can get all the way round.
+func (v *Voxels) Exterior() *Voxels {
+	exterior := NewVoxels()
+	bounds, hasVoxels := v.Bounds()
+	if !hasVoxels {
+		return exterior
+	}
+	box := bounds.Grow(1)
+
+	exterior.Set(box.Min)
+	queue := []Point3{box.Min}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, offset := range Faces {
+			q := p.Add(offset)
+			if box.Contains(q) && !v.Has(q) && !exterior.Has(q) {
+				exterior.Set(q)
+				queue = append(queue, q)
+			}
+		}
+	}
+	return exterior
+}
+
+// ExteriorSurfaceArea counts only the faces of filled voxels that can be
+// reached from outside, leaving out those facing sealed air pockets.
+func (v *Voxels) ExteriorSurfaceArea() int {
+	exterior := v.Exterior()
+	area := 0
+	for p := range v.filled {
+		for _, offset := range Faces {
+			if exterior.Has(p.Add(offset)) {
+				area++
+			}
+		}
+	}
+	return area
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
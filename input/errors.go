@@ -0,0 +1,121 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseError points at the spot in an input that couldn't be parsed.
+type ParseError struct {
+	File string
+	// Line is 1-based.
+	Line int
+	// Column is 1-based, or 0 when the line as a whole is at fault.
+	Column   int
+	Expected string
+	// Text is the offending line, filled in by whoever holds the input.
+	Text string
+	Err  error
+}
+
+// Expected reports that the line at index i (as when ranging over lines)
+// didn't hold what was expected at column.
+func Expected(i, column int, expected string) *ParseError {
+	return &ParseError{Line: i + 1, Column: column, Expected: expected}
+}
+
+// ExpectedErr is Expected along with the error that tripped it.
+func ExpectedErr(i, column int, expected string, err error) *ParseError {
+	return &ParseError{Line: i + 1, Column: column, Expected: expected, Err: err}
+}
+
+func (e *ParseError) Error() string {
+	position := fmt.Sprintf("line %d", e.Line)
+	if e.File != "" {
+		position = fmt.Sprintf("%s:%d", e.File, e.Line)
+	}
+	if e.Column > 0 {
+		position += fmt.Sprintf(":%d", e.Column)
+	}
+
+	message := position + ": expected " + e.Expected
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Snippet renders the offending line with a caret under the column, e.g.
+//
+//	4 |  1  x  3
+//	  |     ^
+func (e *ParseError) Snippet() string {
+	gutter := fmt.Sprintf("%d", e.Line)
+	pad := strings.Repeat(" ", len(gutter))
+
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, "%s | %s\n", gutter, e.Text)
+	if e.Column > 0 {
+		fmt.Fprintf(&sb, "%s | %s^", pad, strings.Repeat(" ", e.Column-1))
+	} else {
+		fmt.Fprintf(&sb, "%s | %s", pad, strings.Repeat("^", max(len(e.Text), 1)))
+	}
+	return sb.String()
+}
+
+// Locate fills in the file and offending line text, leaving anything
+// already set alone.
+func (e *ParseError) Locate(file string, lines []string) {
+	if e.File == "" {
+		e.File = file
+	}
+	if e.Text == "" && e.Line >= 1 && e.Line <= len(lines) {
+		e.Text = lines[e.Line-1]
+	}
+}
+
+// FieldColumn is the 1-based column where the nth (0-based) whitespace
+// separated field of a line starts. A missing field points just past the
+// end of the line.
+func FieldColumn(line string, n int) int {
+	field := -1
+	inField := false
+	for i, r := range line {
+		isSpace := unicode.IsSpace(r)
+		if !isSpace && !inField {
+			field++
+			if field == n {
+				return i + 1
+			}
+		}
+		inField = !isSpace
+	}
+	return len(line) + 1
+}
+
+// SplitColumn is the 1-based column where the nth (0-based) part of a line
+// split by sep starts, or just past the end of the line if there aren't
+// that many parts.
+func SplitColumn(line, sep string, n int) int {
+	column := 1
+	for i := 0; i < n; i++ {
+		next := strings.Index(line[column-1:], sep)
+		if next < 0 {
+			return len(line) + 1
+		}
+		column += next + len(sep)
+	}
+	return column
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,245 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RootEnv overrides the directory searched for <year>/day-<day> folders.
+const RootEnv = "AOC_ROOT"
+
+const (
+	Sample = "sample"
+	Real   = "real"
+)
+
+var ErrNoDayDir = errors.New("day directory not found")
+
+// Root finds the directory holding the <year>/day-<day> folders. It's
+// $AOC_ROOT when set, otherwise the nearest directory at or above the
+// current one that has a day folder, so it works from the repo root as well
+// as from inside a day folder.
+func Root() (string, error) {
+	if root := os.Getenv(RootEnv); root != "" {
+		return root, nil
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		if len(dayDirs(dir)) > 0 {
+			return dir, nil
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	return "", fmt.Errorf("%w: no <year>/day-<day> folders at or above %s", ErrNoDayDir, wd)
+}
+
+// DayDir finds the directory holding a day's solution and inputs.
+func DayDir(year, day int) (string, error) {
+	root, err := Root()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(root, strconv.Itoa(year), fmt.Sprintf("day-%d", day))
+	if !isDir(dir) {
+		return "", fmt.Errorf("%w: %s", ErrNoDayDir, dir)
+	}
+
+	return dir, nil
+}
+
+type YearDay struct {
+	Year, Day int
+}
+
+// Days lists every day folder under the root, in order.
+func Days() ([]YearDay, error) {
+	root, err := Root()
+	if err != nil {
+		return nil, err
+	}
+
+	return dayDirs(root), nil
+}
+
+var dayDirPattern = regexp.MustCompile(`^(\d{4})/day-(\d+)$`)
+
+func dayDirs(root string) []YearDay {
+	matches, _ := filepath.Glob(filepath.Join(root, "[0-9][0-9][0-9][0-9]", "day-*"))
+
+	days := []YearDay{}
+	for _, m := range matches {
+		rel, _ := filepath.Rel(root, m)
+		match := dayDirPattern.FindStringSubmatch(filepath.ToSlash(rel))
+		if match == nil || !isDir(m) {
+			continue
+		}
+		year, _ := strconv.Atoi(match[1])
+		day, _ := strconv.Atoi(match[2])
+		days = append(days, YearDay{year, day})
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Year != days[j].Year {
+			return days[i].Year < days[j].Year
+		}
+		return days[i].Day < days[j].Day
+	})
+
+	return days
+}
+
+// Entry is a named input for a day along with the answers it's known to produce.
+type Entry struct {
+	Name    string         `json:"name"`
+	File    string         `json:"file"`
+	Answers map[int]string `json:"answers,omitempty"`
+}
+
+// Path is the entry's file joined onto the day directory it was listed from.
+func (e Entry) Path(dayDir string) string {
+	if filepath.IsAbs(e.File) {
+		return e.File
+	}
+	return filepath.Join(dayDir, e.File)
+}
+
+// Expected returns the known answer for a part, if the manifest has one.
+func (e Entry) Expected(part int) (string, bool) {
+	answer, hasAnswer := e.Answers[part]
+	return answer, hasAnswer
+}
+
+var conventionalFileName = regexp.MustCompile(`^(sample|input)(\d*)\.txt$`)
+
+// Entries lists every named input for a day. Files following the naming
+// convention are picked up automatically: sample.txt is "sample",
+// sample2.txt is "sample2", input.txt is "real" and input1.txt is "real1".
+// Entries in the day's manifest take precedence over conventional entries
+// with the same name or file.
+func Entries(dayDir string) ([]Entry, error) {
+	manifest, err := LoadManifest(dayDir)
+	if err != nil {
+		return nil, err
+	}
+
+	dirEntries, err := os.ReadDir(dayDir)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]Entry{}
+	listedFiles := map[string]bool{}
+	for _, e := range manifest.Inputs {
+		byName[e.Name] = e
+		listedFiles[e.File] = true
+	}
+
+	for _, de := range dirEntries {
+		match := conventionalFileName.FindStringSubmatch(de.Name())
+		if de.IsDir() || match == nil || listedFiles[de.Name()] {
+			continue
+		}
+		name := Sample
+		if match[1] == "input" {
+			name = Real
+		}
+		name += match[2]
+		if _, isListed := byName[name]; !isListed {
+			byName[name] = Entry{Name: name, File: de.Name()}
+		}
+	}
+
+	entries := []Entry{}
+	for _, e := range byName {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries, nil
+}
+
+// Lookup finds a named input for a day. "sample" and "real" fall back to
+// "sample1" and "real1" for days that number their first input.
+func Lookup(dayDir, name string) (Entry, error) {
+	entries, err := Entries(dayDir)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	candidates := []string{name}
+	if name == Sample || name == Real {
+		candidates = append(candidates, name+"1")
+	}
+	for _, candidate := range candidates {
+		for _, e := range entries {
+			if e.Name == candidate {
+				return e, nil
+			}
+		}
+	}
+
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	return Entry{}, fmt.Errorf("no input named %q in %s (have %v)", name, dayDir, names)
+}
+
+// Resolve turns an --input value into a file path. Anything that names an
+// existing file is used as-is, otherwise it's looked up as a named input in
+// the day's directory.
+func Resolve(year, day int, name string) (string, error) {
+	if isFile(name) {
+		return name, nil
+	}
+
+	dayDir, err := DayDir(year, day)
+	if err != nil {
+		return "", err
+	}
+
+	e, err := Lookup(dayDir, name)
+	if err != nil {
+		return "", err
+	}
+
+	return e.Path(dayDir), nil
+}
+
+// RelPath is path relative to the working directory where it can be, for
+// printing.
+func RelPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,28 @@
+// Package inputtest helps days test their parsers on their inputs.
+package inputtest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Takadimi/aoc/input"
+)
+
+// Seed adds each input of the day being tested to a fuzz target's seed
+// corpus, as the text of the file. Tests run in the day's directory, so
+// that's where its inputs are listed from.
+func Seed(f *testing.F) {
+	f.Helper()
+	entries, err := input.Entries(".")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, e := range entries {
+		data, err := os.ReadFile(e.Path("."))
+		if err != nil {
+			// a listed input that isn't downloaded yet just isn't a seed
+			continue
+		}
+		f.Add(string(data))
+	}
+}
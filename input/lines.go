@@ -1,8 +1,10 @@
-package file
+package input
 
 import (
 	"bufio"
+	"io"
 	"os"
+	"strings"
 )
 
 func Lines(fileName string) ([]string, error) {
@@ -12,7 +14,16 @@ func Lines(fileName string) ([]string, error) {
 	}
 	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
+	return scanLines(f), nil
+}
+
+// SplitLines splits text into lines just as Lines splits a file.
+func SplitLines(text string) []string {
+	return scanLines(strings.NewReader(text))
+}
+
+func scanLines(r io.Reader) []string {
+	scanner := bufio.NewScanner(r)
 	scanner.Split(bufio.ScanLines)
 	result := []string{}
 	for scanner.Scan() {
@@ -20,5 +31,5 @@ func Lines(fileName string) ([]string, error) {
 		result = append(result, line)
 	}
 
-	return result, nil
+	return result
 }
@@ -0,0 +1,51 @@
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ManifestFile is the optional per-day file listing named inputs.
+const ManifestFile = "inputs.json"
+
+// Manifest lists inputs that don't follow the file naming convention, or
+// that have known answers worth checking against.
+//
+//	{
+//		"inputs": [
+//			{"name": "sample", "file": "sample1.txt", "answers": {"1": "10", "2": "36"}},
+//			{"name": "real", "file": "test1.txt"}
+//		]
+//	}
+type Manifest struct {
+	Inputs []Entry `json:"inputs"`
+}
+
+// LoadManifest reads a day's manifest, returning an empty one if the day doesn't have one.
+func LoadManifest(dayDir string) (Manifest, error) {
+	manifestPath := filepath.Join(dayDir, ManifestFile)
+
+	b, err := os.ReadFile(manifestPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return Manifest{}, nil
+	}
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	manifest := Manifest{}
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return Manifest{}, fmt.Errorf("%s: %w", manifestPath, err)
+	}
+
+	for i, e := range manifest.Inputs {
+		if e.Name == "" || e.File == "" {
+			return Manifest{}, fmt.Errorf("%s: input %d needs both a name and a file", manifestPath, i)
+		}
+	}
+
+	return manifest, nil
+}
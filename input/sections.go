@@ -0,0 +1,38 @@
+package input
+
+// Section is a run of lines between blank lines.
+type Section struct {
+	// Start is the index of the section's first line in the whole input.
+	Start int
+	Lines []string
+}
+
+// Sections splits lines on blank lines, remembering where each section
+// started so parse errors can point back into the whole input.
+func Sections(lines []string) []Section {
+	sections := []Section{}
+
+	current := Section{Start: 0, Lines: []string{}}
+	for i, l := range lines {
+		if l == "" {
+			sections = append(sections, current)
+			current = Section{Start: i + 1, Lines: []string{}}
+			continue
+		}
+
+		current.Lines = append(current.Lines, l)
+	}
+	sections = append(sections, current)
+
+	return sections
+}
+
+// Expected is input.Expected for the line at index i within the section.
+func (s Section) Expected(i, column int, expected string) *ParseError {
+	return Expected(s.Start+i, column, expected)
+}
+
+// ExpectedErr is input.ExpectedErr for the line at index i within the section.
+func (s Section) ExpectedErr(i, column int, expected string, err error) *ParseError {
+	return ExpectedErr(s.Start+i, column, expected, err)
+}
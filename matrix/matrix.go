@@ -0,0 +1,218 @@
+// Package matrix does integer linear algebra: products and powers of dense
+// matrices, optionally modulo some m, and solving small linear systems.
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+var ErrOverflow = errors.New("matrix: integer overflow")
+
+// Matrix is a dense integer matrix stored row by row.
+type Matrix struct {
+	Rows, Cols int
+	Data       []int
+}
+
+func New(rows, cols int) *Matrix {
+	return &Matrix{Rows: rows, Cols: cols, Data: make([]int, rows*cols)}
+}
+
+func Identity(n int) *Matrix {
+	m := New(n, n)
+	for i := 0; i < n; i++ {
+		m.Set(i, i, 1)
+	}
+	return m
+}
+
+// FromRows builds a matrix from equal length rows, panicking if they
+// aren't.
+func FromRows(rows [][]int) *Matrix {
+	cols := 0
+	if len(rows) > 0 {
+		cols = len(rows[0])
+	}
+	m := New(len(rows), cols)
+	for r, row := range rows {
+		if len(row) != cols {
+			panic(fmt.Sprintf("matrix: row %d has %d columns, not %d", r, len(row), cols))
+		}
+		copy(m.Data[r*cols:], row)
+	}
+	return m
+}
+
+func (m *Matrix) At(r, c int) int {
+	return m.Data[r*m.Cols+c]
+}
+
+func (m *Matrix) Set(r, c, value int) {
+	m.Data[r*m.Cols+c] = value
+}
+
+func (m *Matrix) String() string {
+	s := ""
+	for r := 0; r < m.Rows; r++ {
+		s += fmt.Sprintln(m.Data[r*m.Cols : (r+1)*m.Cols])
+	}
+	return s
+}
+
+// Mul is a×b, failing with ErrOverflow rather than wrapping around.
+func Mul(a, b *Matrix) (*Matrix, error) {
+	return mul(a, b, 0)
+}
+
+// MulMod is a×b with every entry reduced modulo mod, which must be
+// positive. It can't overflow.
+func MulMod(a, b *Matrix, mod int) *Matrix {
+	checkMod(mod)
+	product, _ := mul(reduce(a, mod), reduce(b, mod), mod)
+	return product
+}
+
+// mul multiplies modulo mod, or checking for overflow when mod is 0.
+func mul(a, b *Matrix, mod int) (*Matrix, error) {
+	if a.Cols != b.Rows {
+		panic(fmt.Sprintf("matrix: can't multiply %dx%d by %dx%d", a.Rows, a.Cols, b.Rows, b.Cols))
+	}
+
+	product := New(a.Rows, b.Cols)
+	for r := 0; r < a.Rows; r++ {
+		for c := 0; c < b.Cols; c++ {
+			sum := 0
+			for k := 0; k < a.Cols; k++ {
+				term, err := multiply(a.At(r, k), b.At(k, c), mod)
+				if err != nil {
+					return nil, err
+				}
+				if sum, err = add(sum, term, mod); err != nil {
+					return nil, err
+				}
+			}
+			product.Set(r, c, sum)
+		}
+	}
+	return product, nil
+}
+
+// Pow is m raised to the nth power by repeated squaring, so it only takes
+// about log2(n) multiplications. m must be square and n not negative.
+func Pow(m *Matrix, n int) (*Matrix, error) {
+	return pow(m, n, 0)
+}
+
+// PowMod is Pow modulo mod.
+func PowMod(m *Matrix, n, mod int) *Matrix {
+	checkMod(mod)
+	power, _ := pow(m, n, mod)
+	return power
+}
+
+func pow(m *Matrix, n, mod int) (*Matrix, error) {
+	if m.Rows != m.Cols {
+		panic(fmt.Sprintf("matrix: can't raise %dx%d to a power", m.Rows, m.Cols))
+	}
+	if n < 0 {
+		panic(fmt.Sprintf("matrix: negative power %d", n))
+	}
+
+	result := Identity(m.Rows)
+	base := m
+	if mod > 0 {
+		result = reduce(result, mod)
+		base = reduce(m, mod)
+	}
+	for n > 0 {
+		var err error
+		if n&1 == 1 {
+			if result, err = mul(result, base, mod); err != nil {
+				return nil, err
+			}
+		}
+		n >>= 1
+		if n > 0 {
+			if base, err = mul(base, base, mod); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// Apply is m×v for a column vector v.
+func Apply(m *Matrix, v []int) ([]int, error) {
+	product, err := mul(m, column(v), 0)
+	if err != nil {
+		return nil, err
+	}
+	return product.Data, nil
+}
+
+// ApplyMod is Apply modulo mod.
+func ApplyMod(m *Matrix, v []int, mod int) []int {
+	checkMod(mod)
+	product, _ := mul(reduce(m, mod), reduce(column(v), mod), mod)
+	return product.Data
+}
+
+func column(v []int) *Matrix {
+	return &Matrix{Rows: len(v), Cols: 1, Data: v}
+}
+
+// checkMod panics on a modulus there are no remainders for. 0 can't be let
+// through, since internally it means checking for overflow instead.
+func checkMod(mod int) {
+	if mod <= 0 {
+		panic(fmt.Sprintf("matrix: modulus %d isn't positive", mod))
+	}
+}
+
+func reduce(m *Matrix, mod int) *Matrix {
+	reduced := New(m.Rows, m.Cols)
+	for i, value := range m.Data {
+		// adding mod only to negative remainders keeps it from overflowing
+		if reduced.Data[i] = value % mod; reduced.Data[i] < 0 {
+			reduced.Data[i] += mod
+		}
+	}
+	return reduced
+}
+
+// multiply is a×b modulo mod for a and b already reduced, or checked for
+// overflow when mod is 0.
+func multiply(a, b, mod int) (int, error) {
+	if mod > 0 {
+		hi, lo := bits.Mul64(uint64(a), uint64(b))
+		return int(bits.Rem64(hi, lo, uint64(mod))), nil
+	}
+
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == minInt) || (b == -1 && a == minInt) {
+		return 0, ErrOverflow
+	}
+	return product, nil
+}
+
+// add is a+b modulo mod for a and b already reduced, or checked for
+// overflow when mod is 0.
+func add(a, b, mod int) (int, error) {
+	if mod > 0 {
+		sum, carry := bits.Add64(uint64(a), uint64(b), 0)
+		return int(bits.Rem64(carry, sum, uint64(mod))), nil
+	}
+
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, ErrOverflow
+	}
+	return sum, nil
+}
+
+const minInt = -1 << (bits.UintSize - 1)
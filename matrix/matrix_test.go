@@ -0,0 +1,97 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestPow(t *testing.T) {
+	fibonacci := FromRows([][]int{{1, 1}, {1, 0}})
+	tests := []struct {
+		n    int
+		want [][]int
+	}{
+		{0, [][]int{{1, 0}, {0, 1}}},
+		{1, [][]int{{1, 1}, {1, 0}}},
+		{10, [][]int{{89, 55}, {55, 34}}},
+		{90, [][]int{{4660046610375530309, 2880067194370816120}, {2880067194370816120, 1779979416004714189}}},
+	}
+	for _, test := range tests {
+		got, err := Pow(fibonacci, test.n)
+		if err != nil {
+			t.Errorf("Pow(fibonacci, %d): %v", test.n, err)
+			continue
+		}
+		if want := FromRows(test.want); !reflect.DeepEqual(got, want) {
+			t.Errorf("Pow(fibonacci, %d) = %v, want %v", test.n, got.Data, want.Data)
+		}
+	}
+
+	if _, err := Pow(fibonacci, 93); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Pow(fibonacci, 93) error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestMod(t *testing.T) {
+	tests := []struct {
+		name string
+		got  *Matrix
+		want [][]int
+	}{
+		{"MulMod negative", MulMod(FromRows([][]int{{-1}}), FromRows([][]int{{1}}), 7), [][]int{{6}}},
+		{"MulMod both negative", MulMod(FromRows([][]int{{-3, 2}}), FromRows([][]int{{-4}, {-1}}), 7), [][]int{{3}}},
+		{"MulMod large", MulMod(FromRows([][]int{{math.MaxInt - 1}}), FromRows([][]int{{math.MaxInt - 1}}), math.MaxInt), [][]int{{1}}},
+		{"PowMod negative", PowMod(FromRows([][]int{{-1}}), 1, 7), [][]int{{6}}},
+		{"PowMod fibonacci", PowMod(FromRows([][]int{{1, 1}, {1, 0}}), 1000, 1_000_000_007), [][]int{{107579939, 517691607}, {517691607, 589888339}}},
+		{"ApplyMod negative", column(ApplyMod(FromRows([][]int{{-1}}), []int{1}, 7)), [][]int{{6}}},
+		{"ApplyMod negative vector", column(ApplyMod(FromRows([][]int{{2, 3}}), []int{-1, -2}, 5)), [][]int{{2}}},
+	}
+	for _, test := range tests {
+		if want := FromRows(test.want); !reflect.DeepEqual(test.got, want) {
+			t.Errorf("%s = %v, want %v", test.name, test.got.Data, want.Data)
+		}
+	}
+}
+
+func TestModNotPositive(t *testing.T) {
+	for _, mod := range []int{0, -7} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("MulMod with modulus %d didn't panic", mod)
+				}
+			}()
+			MulMod(Identity(1), Identity(1), mod)
+		}()
+	}
+}
+
+func TestSolve(t *testing.T) {
+	// x + y + z = 6, 2y + 5z = -4, 2x + 5y - z = 27
+	a := FromRows([][]int{{1, 1, 1}, {0, 2, 5}, {2, 5, -1}})
+	x, err := Solve(a, []int{6, -4, 27})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []int64{5, 3, -2} {
+		if x[i].Cmp(big.NewRat(want, 1)) != 0 {
+			t.Errorf("x[%d] = %v, want %d", i, x[i], want)
+		}
+	}
+
+	// needs a row swap and has a fractional answer: 2y = 1, 3x = 2
+	x, err = Solve(FromRows([][]int{{0, 2}, {3, 0}}), []int{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x[0].Cmp(big.NewRat(2, 3)) != 0 || x[1].Cmp(big.NewRat(1, 2)) != 0 {
+		t.Errorf("x = %v, want [2/3 1/2]", x)
+	}
+
+	if _, err := Solve(FromRows([][]int{{1, 2}, {2, 4}}), []int{3, 6}); !errors.Is(err, ErrSingular) {
+		t.Errorf("singular system error = %v, want ErrSingular", err)
+	}
+}
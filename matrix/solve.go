@@ -0,0 +1,65 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var ErrSingular = errors.New("matrix: system has no unique solution")
+
+// Solve finds x with a×x = b by Gaussian elimination, using exact rationals
+// so there's no rounding to worry about. It's meant for the handful of
+// unknowns puzzles tend to have; a must be square.
+func Solve(a *Matrix, b []int) ([]*big.Rat, error) {
+	n := a.Rows
+	if a.Cols != n || len(b) != n {
+		panic(fmt.Sprintf("matrix: can't solve %dx%d with %d constants", a.Rows, a.Cols, len(b)))
+	}
+
+	// the augmented matrix [a | b]
+	rows := make([][]*big.Rat, n)
+	for r := range rows {
+		rows[r] = make([]*big.Rat, n+1)
+		for c := 0; c < n; c++ {
+			rows[r][c] = big.NewRat(int64(a.At(r, c)), 1)
+		}
+		rows[r][n] = big.NewRat(int64(b[r]), 1)
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := -1
+		for r := col; r < n; r++ {
+			if rows[r][col].Sign() != 0 {
+				pivot = r
+				break
+			}
+		}
+		if pivot < 0 {
+			return nil, ErrSingular
+		}
+		rows[col], rows[pivot] = rows[pivot], rows[col]
+
+		// scale the pivot row to a leading 1 and clear the column from
+		// every other row
+		inverse := new(big.Rat).Inv(rows[col][col])
+		for c := col; c <= n; c++ {
+			rows[col][c].Mul(rows[col][c], inverse)
+		}
+		for r := 0; r < n; r++ {
+			if r == col || rows[r][col].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(rows[r][col])
+			for c := col; c <= n; c++ {
+				rows[r][c].Sub(rows[r][c], new(big.Rat).Mul(factor, rows[col][c]))
+			}
+		}
+	}
+
+	x := make([]*big.Rat, n)
+	for r := range x {
+		x[r] = rows[r][n]
+	}
+	return x, nil
+}
@@ -0,0 +1,83 @@
+// Package memo caches the results of recursive functions and fills
+// dynamic programming tables.
+package memo
+
+import "container/list"
+
+type Stats struct {
+	Hits, Misses, Evictions int
+}
+
+// Memo wraps a recursive function so each key is only worked out once. The
+// function is handed a recurse func to call instead of calling itself, which
+// goes through the cache.
+type Memo[K comparable, V any] struct {
+	f func(recurse func(K) V, key K) V
+	// limit caps how many results are kept, 0 keeps all of them. Once full
+	// the least recently used result makes way for the next.
+	limit   int
+	entries map[K]*list.Element
+	recent  *list.List
+	stats   Stats
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func New[K comparable, V any](f func(recurse func(K) V, key K) V) *Memo[K, V] {
+	return NewLimited(0, f)
+}
+
+// NewLimited is New keeping at most limit results.
+func NewLimited[K comparable, V any](limit int, f func(recurse func(K) V, key K) V) *Memo[K, V] {
+	return &Memo[K, V]{
+		f:       f,
+		limit:   limit,
+		entries: make(map[K]*list.Element),
+		recent:  list.New(),
+	}
+}
+
+func (m *Memo[K, V]) Get(key K) V {
+	if e, isCached := m.entries[key]; isCached {
+		m.stats.Hits++
+		m.recent.MoveToFront(e)
+		return e.Value.(entry[K, V]).value
+	}
+
+	m.stats.Misses++
+	value := m.f(m.Get, key)
+
+	// the recursion may have cached key already
+	if e, isCached := m.entries[key]; isCached {
+		m.recent.MoveToFront(e)
+		return value
+	}
+	m.entries[key] = m.recent.PushFront(entry[K, V]{key, value})
+	if m.limit > 0 && m.recent.Len() > m.limit {
+		oldest := m.recent.Back()
+		m.recent.Remove(oldest)
+		delete(m.entries, oldest.Value.(entry[K, V]).key)
+		m.stats.Evictions++
+	}
+
+	return value
+}
+
+// Len is the number of results currently cached.
+func (m *Memo[K, V]) Len() int {
+	return len(m.entries)
+}
+
+func (m *Memo[K, V]) Stats() Stats {
+	return m.stats
+}
+
+// Reset forgets every cached result and the stats.
+func (m *Memo[K, V]) Reset() {
+	m.entries = make(map[K]*list.Element)
+	m.recent.Init()
+	m.stats = Stats{}
+}
@@ -0,0 +1,11 @@
+package memo
+
+// Table fills a table of n entries bottom-up, in index order, so each entry
+// can be worked out from the ones before it.
+func Table[V any](n int, f func(i int, table []V) V) []V {
+	table := make([]V, 0, n)
+	for i := 0; i < n; i++ {
+		table = append(table, f(i, table))
+	}
+	return table
+}
@@ -0,0 +1,137 @@
+// Package parallel spreads independent work over index ranges and slices
+// across goroutines, at most one per GOMAXPROCS.
+package parallel
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// chunksPerWorker splits work finer than one chunk per worker, so a worker
+// that draws cheap chunks can pick up more instead of sitting idle.
+const chunksPerWorker = 4
+
+type chunk struct {
+	start, end int
+}
+
+// chunks splits 0 to n-1 into contiguous ranges in order.
+func chunks(n, workers int) []chunk {
+	size := (n + workers*chunksPerWorker - 1) / (workers * chunksPerWorker)
+	if size < 1 {
+		size = 1
+	}
+	cs := []chunk{}
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+		cs = append(cs, chunk{start, end})
+	}
+	return cs
+}
+
+func workers(n int) int {
+	w := runtime.GOMAXPROCS(0)
+	if w > n {
+		w = n
+	}
+	if w < 1 {
+		w = 1
+	}
+	return w
+}
+
+// eachChunk calls body for every chunk of 0 to n-1 across the workers,
+// stopping handing out chunks once ctx is done.
+func eachChunk(ctx context.Context, n int, body func(c int, start, end int)) error {
+	if n <= 0 {
+		return ctx.Err()
+	}
+
+	w := workers(n)
+	cs := chunks(n, w)
+	queue := make(chan int)
+	wg := sync.WaitGroup{}
+	for i := 0; i < w; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range queue {
+				body(c, cs[c].start, cs[c].end)
+			}
+		}()
+	}
+
+	var err error
+	for c := range cs {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		queue <- c
+	}
+	close(queue)
+	wg.Wait()
+
+	if err == nil {
+		err = ctx.Err()
+	}
+	return err
+}
+
+// For calls body for every i from 0 to n-1, in no particular order and
+// several at once. It returns ctx's error if ctx is done before every call
+// has been made, though calls already under way still finish.
+func For(ctx context.Context, n int, body func(i int)) error {
+	return eachChunk(ctx, n, func(_ int, start, end int) {
+		for i := start; i < end; i++ {
+			body(i)
+		}
+	})
+}
+
+// MapReduce maps every i from 0 to n-1 and folds the results into initial
+// with reduce. Each chunk is folded on its own and the chunks are then
+// folded together in index order, so the answer is the same on every run
+// as long as reduce is associative; it needn't be commutative.
+func MapReduce[A any](ctx context.Context, n int, mapper func(i int) A, reduce func(acc, a A) A, initial A) (A, error) {
+	w := workers(n)
+	partials := make([]A, len(chunks(n, w)))
+	err := eachChunk(ctx, n, func(c int, start, end int) {
+		acc := mapper(start)
+		for i := start + 1; i < end; i++ {
+			acc = reduce(acc, mapper(i))
+		}
+		partials[c] = acc
+	})
+	if err != nil {
+		return initial, err
+	}
+
+	acc := initial
+	for _, partial := range partials {
+		acc = reduce(acc, partial)
+	}
+	return acc, nil
+}
+
+// Map applies f to every element of s, keeping their order.
+func Map[T, A any](ctx context.Context, s []T, f func(T) A) ([]A, error) {
+	mapped := make([]A, len(s))
+	err := For(ctx, len(s), func(i int) {
+		mapped[i] = f(s[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapped, nil
+}
+
+// Reduce is MapReduce over the elements of a slice.
+func Reduce[T, A any](ctx context.Context, s []T, mapper func(T) A, reduce func(acc, a A) A, initial A) (A, error) {
+	return MapReduce(ctx, len(s), func(i int) A {
+		return mapper(s[i])
+	}, reduce, initial)
+}
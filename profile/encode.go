@@ -0,0 +1,117 @@
+package profile
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// Write writes the profile gzipped in the format runtime/pprof does, for
+// Parse or the pprof tool to read. There are no addresses to go on, so
+// each function gets a location of its own.
+func (p *Profile) Write(w io.Writer) error {
+	strings := []string{""}
+	stringIndex := map[string]uint64{"": 0}
+	str := func(s string) uint64 {
+		i, isSeen := stringIndex[s]
+		if !isSeen {
+			i = uint64(len(strings))
+			stringIndex[s] = i
+			strings = append(strings, s)
+		}
+		return i
+	}
+
+	out := encoder{}
+	for i, typ := range p.SampleTypes {
+		unit := ""
+		if i < len(p.SampleUnits) {
+			unit = p.SampleUnits[i]
+		}
+		valueType := encoder{}
+		valueType.uint64(1, str(typ))
+		valueType.uint64(2, str(unit))
+		out.bytes(1, valueType.data)
+	}
+
+	functions := map[string]uint64{}
+	order := []string{}
+	for _, s := range p.Samples {
+		ids := []uint64{}
+		for _, function := range s.Stack {
+			id, isSeen := functions[function]
+			if !isSeen {
+				id = uint64(len(functions) + 1)
+				functions[function] = id
+				order = append(order, function)
+			}
+			ids = append(ids, id)
+		}
+		values := []uint64{}
+		for _, v := range s.Values {
+			values = append(values, uint64(v))
+		}
+
+		sample := encoder{}
+		sample.packed(1, ids)
+		sample.packed(2, values)
+		out.bytes(2, sample.data)
+	}
+
+	for _, function := range order {
+		id := functions[function]
+		line := encoder{}
+		line.uint64(1, id)
+		location := encoder{}
+		location.uint64(1, id)
+		location.bytes(4, line.data)
+		out.bytes(4, location.data)
+	}
+	for _, function := range order {
+		f := encoder{}
+		f.uint64(1, functions[function])
+		f.uint64(2, str(function))
+		out.bytes(5, f.data)
+	}
+	for _, s := range strings {
+		out.bytes(6, []byte(s))
+	}
+
+	gz := gzip.NewWriter(w)
+	if _, err := gz.Write(out.data); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// encoder builds up the fields of a protobuf message.
+type encoder struct {
+	data []byte
+}
+
+func (e *encoder) varint(v uint64) {
+	for v >= 0x80 {
+		e.data = append(e.data, byte(v)|0x80)
+		v >>= 7
+	}
+	e.data = append(e.data, byte(v))
+}
+
+func (e *encoder) uint64(number int, v uint64) {
+	e.varint(uint64(number)<<3 | varint)
+	e.varint(v)
+}
+
+func (e *encoder) bytes(number int, b []byte) {
+	e.varint(uint64(number)<<3 | delimited)
+	e.varint(uint64(len(b)))
+	e.data = append(e.data, b...)
+}
+
+// packed writes a repeated integer field as a single packed one.
+func (e *encoder) packed(number int, values []uint64) {
+	packed := encoder{}
+	for _, v := range values {
+		packed.varint(v)
+	}
+	e.bytes(number, packed.data)
+}
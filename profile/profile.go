@@ -0,0 +1,287 @@
+// Package profile reads the pprof profiles runtime/pprof writes, enough to
+// say which functions the time or memory went on without the pprof tool.
+package profile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Profile is the part of a pprof profile that's needed to rank functions:
+// each sample's call stack, innermost function first, and its values.
+type Profile struct {
+	// SampleTypes names each of a sample's values, like "cpu" or
+	// "alloc_space", and SampleUnits what they count, like "bytes".
+	SampleTypes []string
+	SampleUnits []string
+	Samples     []Sample
+}
+
+type Sample struct {
+	Stack  []string
+	Values []int64
+}
+
+// Parse reads a profile, which is usually gzipped.
+func Parse(r io.Reader) (*Profile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if data, err = io.ReadAll(gz); err != nil {
+			return nil, err
+		}
+	}
+
+	p, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("profile: %w", err)
+	}
+	return p, nil
+}
+
+// rawSample is a sample before its locations are resolved to functions.
+type rawSample struct {
+	locations []uint64
+	values    []int64
+}
+
+// decode picks the fields it needs out of profile.proto's Profile message:
+// sample types (1), samples (2), locations (4), functions (5) and the
+// string table (6) everything else refers to by index.
+func decode(data []byte) (*Profile, error) {
+	strings := []string{}
+	sampleTypes, sampleUnits := []uint64{}, []uint64{}
+	samples := []rawSample{}
+	// locations maps a location to the functions at it, innermost first,
+	// since inlined calls share a location with their caller
+	locations := map[uint64][]uint64{}
+	functions := map[uint64]uint64{}
+
+	m := message{data: data}
+	for !m.done() {
+		f, err := m.next()
+		if err != nil {
+			return nil, err
+		}
+
+		switch f.Number {
+		case 1:
+			typ, unit, err := decodeValueType(f.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			sampleTypes = append(sampleTypes, typ)
+			sampleUnits = append(sampleUnits, unit)
+		case 2:
+			s, err := decodeSample(f.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			samples = append(samples, s)
+		case 4:
+			id, functionIDs, err := decodeLocation(f.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			locations[id] = functionIDs
+		case 5:
+			id, name, err := decodeFunction(f.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			functions[id] = name
+		case 6:
+			strings = append(strings, string(f.Bytes))
+		}
+	}
+
+	str := func(i uint64) string {
+		if i >= uint64(len(strings)) {
+			return fmt.Sprintf("?%d", i)
+		}
+		return strings[i]
+	}
+
+	p := &Profile{}
+	for i, typ := range sampleTypes {
+		p.SampleTypes = append(p.SampleTypes, str(typ))
+		p.SampleUnits = append(p.SampleUnits, str(sampleUnits[i]))
+	}
+	for _, s := range samples {
+		stack := []string{}
+		for _, location := range s.locations {
+			for _, function := range locations[location] {
+				stack = append(stack, str(functions[function]))
+			}
+		}
+		p.Samples = append(p.Samples, Sample{Stack: stack, Values: s.values})
+	}
+	return p, nil
+}
+
+// decodeValueType is the string indexes of a ValueType's type and unit.
+func decodeValueType(data []byte) (uint64, uint64, error) {
+	m := message{data: data}
+	typ, unit := uint64(0), uint64(0)
+	for !m.done() {
+		f, err := m.next()
+		if err != nil {
+			return 0, 0, err
+		}
+
+		switch f.Number {
+		case 1:
+			typ = f.Value
+		case 2:
+			unit = f.Value
+		}
+	}
+	return typ, unit, nil
+}
+
+func decodeSample(data []byte) (rawSample, error) {
+	m := message{data: data}
+	s := rawSample{}
+	for !m.done() {
+		f, err := m.next()
+		if err != nil {
+			return s, err
+		}
+
+		switch f.Number {
+		case 1:
+			ids, err := f.uint64s()
+			if err != nil {
+				return s, err
+			}
+			s.locations = append(s.locations, ids...)
+		case 2:
+			values, err := f.uint64s()
+			if err != nil {
+				return s, err
+			}
+			for _, v := range values {
+				s.values = append(s.values, int64(v))
+			}
+		}
+	}
+	return s, nil
+}
+
+func decodeLocation(data []byte) (uint64, []uint64, error) {
+	m := message{data: data}
+	id := uint64(0)
+	functionIDs := []uint64{}
+	for !m.done() {
+		f, err := m.next()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch f.Number {
+		case 1:
+			id = f.Value
+		case 4:
+			// a Line, whose first field is its function
+			line := message{data: f.Bytes}
+			for !line.done() {
+				lf, err := line.next()
+				if err != nil {
+					return 0, nil, err
+				}
+				if lf.Number == 1 {
+					functionIDs = append(functionIDs, lf.Value)
+				}
+			}
+		}
+	}
+	return id, functionIDs, nil
+}
+
+func decodeFunction(data []byte) (uint64, uint64, error) {
+	m := message{data: data}
+	id, name := uint64(0), uint64(0)
+	for !m.done() {
+		f, err := m.next()
+		if err != nil {
+			return 0, 0, err
+		}
+
+		switch f.Number {
+		case 1:
+			id = f.Value
+		case 2:
+			name = f.Value
+		}
+	}
+	return id, name, nil
+}
+
+// Entry is how much of a sample value went on one function. Flat counts
+// samples where the function itself was running and Cum adds those where
+// it was further up the stack.
+type Entry struct {
+	Function  string
+	Flat, Cum int64
+}
+
+// Top ranks functions by flat value of the sample type at index, the n
+// largest first, along with the total over every sample. A negative n
+// keeps them all.
+func (p *Profile) Top(index, n int) ([]Entry, int64) {
+	entries := map[string]*Entry{}
+	entry := func(function string) *Entry {
+		e, isSeen := entries[function]
+		if !isSeen {
+			e = &Entry{Function: function}
+			entries[function] = e
+		}
+		return e
+	}
+
+	total := int64(0)
+	for _, s := range p.Samples {
+		if index >= len(s.Values) || len(s.Stack) == 0 {
+			continue
+		}
+		value := s.Values[index]
+		total += value
+
+		entry(s.Stack[0]).Flat += value
+		// recursive functions only count once towards each sample
+		counted := map[string]bool{}
+		for _, function := range s.Stack {
+			if !counted[function] {
+				counted[function] = true
+				entry(function).Cum += value
+			}
+		}
+	}
+
+	top := []Entry{}
+	for _, e := range entries {
+		top = append(top, *e)
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Flat != top[j].Flat {
+			return top[i].Flat > top[j].Flat
+		}
+		if top[i].Cum != top[j].Cum {
+			return top[i].Cum > top[j].Cum
+		}
+		return top[i].Function < top[j].Function
+	})
+	if n >= 0 && n < len(top) {
+		top = top[:n]
+	}
+	return top, total
+}
@@ -0,0 +1,206 @@
+package profile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"testing"
+	"time"
+)
+
+var sink []byte
+
+//go:noinline
+func allocateLots() {
+	for i := 0; i < 1000; i++ {
+		sink = make([]byte, 1024)
+	}
+}
+
+// TestParseAllocs round trips an allocs profile from runtime/pprof.
+func TestParseAllocs(t *testing.T) {
+	defer func(rate int) { runtime.MemProfileRate = rate }(runtime.MemProfileRate)
+	runtime.MemProfileRate = 1
+
+	allocateLots()
+	runtime.GC()
+	raw := bytes.Buffer{}
+	if err := pprof.Lookup("allocs").WriteTo(&raw, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Parse(&raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantTypes := []string{"alloc_objects", "alloc_space", "inuse_objects", "inuse_space"}
+	wantUnits := []string{"count", "bytes", "count", "bytes"}
+	if strings.Join(p.SampleTypes, " ") != strings.Join(wantTypes, " ") || strings.Join(p.SampleUnits, " ") != strings.Join(wantUnits, " ") {
+		t.Fatalf("sample types %v in %v, want %v in %v", p.SampleTypes, p.SampleUnits, wantTypes, wantUnits)
+	}
+
+	top, total := p.Top(1, -1)
+	entry := findEntry(top, "profile.allocateLots")
+	if entry == nil {
+		t.Fatalf("allocateLots isn't in the profile: %v", top)
+	}
+	if entry.Flat < 1000*1024 || entry.Flat > total {
+		t.Errorf("allocateLots allocated %d bytes of %d, want at least %d", entry.Flat, total, 1000*1024)
+	}
+}
+
+//go:noinline
+func spin(d int) int {
+	x := 0
+	for i := 0; i < d; i++ {
+		x = x*31 + i
+	}
+	return x
+}
+
+// TestParseCPU round trips a CPU profile from runtime/pprof.
+func TestParseCPU(t *testing.T) {
+	if testing.Short() {
+		t.Skip("takes a CPU profile")
+	}
+	raw := bytes.Buffer{}
+	if err := pprof.StartCPUProfile(&raw); err != nil {
+		t.Skip(err)
+	}
+	// long enough for the profiler to take a couple of dozen samples
+	for start := time.Now(); time.Since(start) < 250*time.Millisecond; {
+		sink = append(sink[:0], byte(spin(100_000)))
+	}
+	pprof.StopCPUProfile()
+
+	p, err := Parse(&raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	top, _ := p.Top(len(p.SampleTypes)-1, 1)
+	if len(top) == 0 || !strings.HasSuffix(top[0].Function, "profile.spin") {
+		t.Errorf("top function = %v, want spin", top)
+	}
+}
+
+// TestWrite round trips a profile through Write.
+func TestWrite(t *testing.T) {
+	p := &Profile{
+		SampleTypes: []string{"alloc_objects", "alloc_space"},
+		SampleUnits: []string{"count", "bytes"},
+		Samples: []Sample{
+			{Stack: []string{"a", "b", "main"}, Values: []int64{1, 100}},
+			{Stack: []string{"b", "main"}, Values: []int64{2, 50}},
+			{Stack: []string{"a", "a", "main"}, Values: []int64{-1, 25}},
+		},
+	}
+	raw := bytes.Buffer{}
+	if err := p.Write(&raw); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Parse(&raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	top, total := got.Top(1, -1)
+	if total != 175 {
+		t.Errorf("total = %d, want 175", total)
+	}
+	want := []Entry{{"a", 125, 125}, {"b", 50, 150}, {"main", 0, 175}}
+	if len(top) != len(want) {
+		t.Fatalf("top = %v, want %v", top, want)
+	}
+	for i := range want {
+		if top[i] != want[i] {
+			t.Errorf("top[%d] = %v, want %v", i, top[i], want[i])
+		}
+	}
+	if v := got.Samples[2].Values[0]; v != -1 {
+		t.Errorf("negative value came back as %d", v)
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"truncated varint", []byte{0x08, 0x80}},
+		{"truncated key", []byte{0x80}},
+		{"varint too long", append([]byte{0x08}, bytes.Repeat([]byte{0xff}, 10)...)},
+		{"truncated fixed64", []byte{0x09, 1, 2, 3}},
+		{"truncated fixed32", []byte{0x0d, 1}},
+		{"length past the end", []byte{0x12, 0x05, 0x08}},
+		{"huge length", []byte{0x12, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}},
+		{"unsupported wire type", []byte{0x0b}},
+		{"truncated sample", []byte{0x12, 0x02, 0x08, 0x80}},
+		{"truncated packed values", []byte{0x12, 0x03, 0x12, 0x01, 0x80}},
+		{"truncated location line", []byte{0x22, 0x04, 0x22, 0x02, 0x08, 0x80}},
+		{"truncated function", []byte{0x2a, 0x02, 0x10, 0x80}},
+		{"truncated value type", []byte{0x0a, 0x02, 0x08, 0x80}},
+	}
+	for _, test := range tests {
+		if _, err := Parse(bytes.NewReader(test.data)); err == nil {
+			t.Errorf("%s: parsed without an error", test.name)
+		}
+	}
+
+	if _, err := Parse(bytes.NewReader([]byte{0x1f, 0x8b, 0x08})); err == nil {
+		t.Error("truncated gzip header parsed without an error")
+	}
+
+	// a whole profile gzipped but cut short
+	gzipped := bytes.Buffer{}
+	gz := gzip.NewWriter(&gzipped)
+	gz.Write(bytes.Repeat([]byte{0x30, 0x01}, 1000))
+	gz.Close()
+	cut := gzipped.Bytes()[:gzipped.Len()/2]
+	if _, err := Parse(bytes.NewReader(cut)); err == nil {
+		t.Error("truncated gzip parsed without an error")
+	}
+}
+
+// TestParseTruncatedProfile cuts a real profile short at a few hundred
+// lengths. Cuts between fields still parse, but the rest must say the
+// profile was truncated.
+func TestParseTruncatedProfile(t *testing.T) {
+	raw := bytes.Buffer{}
+	if err := pprof.Lookup("allocs").WriteTo(&raw, 0); err != nil {
+		t.Fatal(err)
+	}
+	gz, err := gzip.NewReader(&raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Buffer{}
+	if _, err := data.ReadFrom(gz); err != nil {
+		t.Fatal(err)
+	}
+
+	failed := 0
+	for n := 0; n < data.Len(); n += 1 + data.Len()/300 {
+		_, err := Parse(bytes.NewReader(data.Bytes()[:n]))
+		if err != nil {
+			failed++
+			if !errors.Is(err, errTruncated) {
+				t.Fatalf("cut to %d bytes: unexpected error %v", n, err)
+			}
+		}
+	}
+	if failed == 0 {
+		t.Error("no cut short profile failed to parse")
+	}
+}
+
+func findEntry(entries []Entry, suffix string) *Entry {
+	for i := range entries {
+		if strings.HasSuffix(entries[i].Function, suffix) {
+			return &entries[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The protobuf wire types a profile uses.
+const (
+	varint    = 0
+	fixed64   = 1
+	delimited = 2
+	fixed32   = 5
+)
+
+var errTruncated = errors.New("truncated message")
+
+// message reads the fields of an encoded protobuf message in turn.
+type message struct {
+	data []byte
+}
+
+// field is one field of a message. Varint and fixed fields have their value
+// in Value and delimited ones their contents in Bytes.
+type field struct {
+	Number   int
+	WireType int
+	Value    uint64
+	Bytes    []byte
+}
+
+func (m *message) done() bool {
+	return len(m.data) == 0
+}
+
+func (m *message) varint() (uint64, error) {
+	value := uint64(0)
+	for shift := 0; shift < 64; shift += 7 {
+		if len(m.data) == 0 {
+			return 0, errTruncated
+		}
+		b := m.data[0]
+		m.data = m.data[1:]
+		value |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return value, nil
+		}
+	}
+	return 0, errors.New("varint too long")
+}
+
+func (m *message) fixed(size int) (uint64, error) {
+	if len(m.data) < size {
+		return 0, errTruncated
+	}
+	value := uint64(0)
+	for i := size - 1; i >= 0; i-- {
+		value = value<<8 | uint64(m.data[i])
+	}
+	m.data = m.data[size:]
+	return value, nil
+}
+
+func (m *message) next() (field, error) {
+	key, err := m.varint()
+	if err != nil {
+		return field{}, err
+	}
+	f := field{Number: int(key >> 3), WireType: int(key & 7)}
+
+	switch f.WireType {
+	case varint:
+		f.Value, err = m.varint()
+	case fixed64:
+		f.Value, err = m.fixed(8)
+	case fixed32:
+		f.Value, err = m.fixed(4)
+	case delimited:
+		var length uint64
+		length, err = m.varint()
+		if err == nil && length > uint64(len(m.data)) {
+			err = errTruncated
+		}
+		if err == nil {
+			f.Bytes, m.data = m.data[:length], m.data[length:]
+		}
+	default:
+		err = fmt.Errorf("unsupported wire type %d in field %d", f.WireType, f.Number)
+	}
+	return f, err
+}
+
+// uint64s is the values of a repeated integer field, which encoders may
+// write either one value per field or packed together in one.
+func (f field) uint64s() ([]uint64, error) {
+	if f.WireType != delimited {
+		return []uint64{f.Value}, nil
+	}
+	packed := message{data: f.Bytes}
+	values := []uint64{}
+	for !packed.done() {
+		v, err := packed.varint()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
@@ -0,0 +1,167 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/Takadimi/aoc/input"
+)
+
+// InputFiles lists the .txt files in a directory of inputs, leaving out
+// hidden ones. Taking only .txt files means a day's own directory can be
+// given without its code, manifest or test data being run as inputs.
+func InputFiles(dir string) ([]string, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for _, de := range dirEntries {
+		if de.IsDir() || filepath.Ext(de.Name()) != ".txt" || strings.HasPrefix(de.Name(), ".") {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, de.Name()))
+	}
+	sort.Strings(paths)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no inputs in %s", dir)
+	}
+	return paths, nil
+}
+
+// RunInputs solves a day's parts, and every variant of them, on each input
+// file in a directory. Results come back in input, part, then variant order.
+// A manifest in the directory can list answers the inputs are known to
+// produce. Profiles and checkpoints aren't taken, since each part runs more
+// than once.
+func RunInputs(ctx context.Context, d Day, dir string, opts Options) ([]Result, error) {
+	paths, err := InputFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := input.LoadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	known := map[string]input.Entry{}
+	for _, e := range manifest.Inputs {
+		known[filepath.Base(e.File)] = e
+	}
+
+	if opts.Workers < 1 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+	opts.Profiles, opts.Checkpoints = Profiles{}, Checkpoints{}
+
+	tasks := []task{}
+	results := []Result{}
+	for _, path := range paths {
+		name := filepath.Base(path)
+		entry := known[name]
+		lines, err := input.Lines(path)
+
+		for part := 1; part <= len(d.Parts); part++ {
+			if !wantsPart(opts.Parts, part) {
+				continue
+			}
+			fns := []PartFunc{d.Parts[part-1]}
+			variants := []string{""}
+			for _, v := range d.Variants {
+				if v.Part == part {
+					fns = append(fns, v.Func)
+					variants = append(variants, v.Name)
+				}
+			}
+
+			for i, fn := range fns {
+				r := Result{Year: d.Year, Day: d.Day, Part: part, Variant: variants[i], Input: name, Path: path, Err: err}
+				if !opts.SkipExpected {
+					r.Expected, r.HasExpected = entry.Expected(part)
+				}
+				results = append(results, r)
+				tasks = append(tasks, task{day: d, part: part, fn: fn, path: path, lines: lines})
+			}
+		}
+	}
+
+	runTasks(ctx, tasks, results, opts)
+	return results, nil
+}
+
+// Disagreement is a variant that came to a different answer from its part
+// on the same input, or failed where the part didn't, or the other way
+// round.
+type Disagreement struct {
+	Part, Variant Result
+}
+
+func (d Disagreement) String() string {
+	describe := func(r Result) string {
+		if r.Err != nil {
+			return "fails with " + r.Err.Error()
+		}
+		return "says " + r.Answer
+	}
+	return fmt.Sprintf("%s %s but the part %s", d.Variant.Variant, describe(d.Variant), describe(d.Part))
+}
+
+// Flagged lists the inputs of RunInputs' results where a part failed or a
+// variant disagreed with its part.
+func Flagged(results []Result) []string {
+	parts := map[inputPart]Result{}
+	for _, r := range results {
+		if r.Variant == "" {
+			parts[inputPart{r.Path, r.Part}] = r
+		}
+	}
+	flags, _ := inputFlags(results, parts)
+
+	paths := []string{}
+	for path := range flags {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// inputPart is a part run on a particular input.
+type inputPart struct {
+	path string
+	part int
+}
+
+// Disagreements finds every variant result that doesn't agree with the
+// result of its part on the same input. Results from timed out or
+// cancelled runs are left out, since they never came to an answer.
+func Disagreements(results []Result) []Disagreement {
+	disagreements := []Disagreement{}
+	parts := map[inputPart]Result{}
+	for _, r := range results {
+		key := inputPart{r.Path, r.Part}
+		if r.Variant == "" {
+			parts[key] = r
+			continue
+		}
+		part, hasPart := parts[key]
+		if !hasPart || unfinished(part) || unfinished(r) {
+			continue
+		}
+		bothFailed := part.Err != nil && r.Err != nil
+		if !bothFailed && ((part.Err == nil) != (r.Err == nil) || part.Answer != r.Answer) {
+			disagreements = append(disagreements, Disagreement{Part: part, Variant: r})
+		}
+	}
+	return disagreements
+}
+
+// unfinished reports whether a part was stopped before it could answer.
+func unfinished(r Result) bool {
+	return errors.Is(r.Err, ErrTimeout) || errors.Is(r.Err, context.Canceled)
+}
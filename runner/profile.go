@@ -0,0 +1,228 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
+
+	"github.com/Takadimi/aoc/profile"
+)
+
+// Profiles names the files to profile each part into, any left empty being
+// skipped. Every part gets its own files, named after it by PartPath.
+type Profiles struct {
+	CPU   string
+	Mem   string
+	Trace string
+}
+
+func (p Profiles) Enabled() bool {
+	return p.CPU != "" || p.Mem != "" || p.Trace != ""
+}
+
+// PartPath works a part's year, day and part number into a file name ahead
+// of its extension, so cpu.prof becomes cpu-2021-12-2.prof.
+func PartPath(name string, year, day, part int) string {
+	ext := filepath.Ext(name)
+	return fmt.Sprintf("%s-%d-%d-%d%s", strings.TrimSuffix(name, ext), year, day, part, ext)
+}
+
+// start begins profiling a part, returning what finishes it off. The CPU
+// profiler and tracer are global, so only one part can be profiled at once.
+func (p Profiles) start(year, day, part int) (func() error, error) {
+	stops := []func() error{}
+	// stop finishes everything started, reporting the first thing to fail
+	stop := func() error {
+		var firstErr error
+		for _, s := range stops {
+			if err := s(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+
+	if p.CPU != "" {
+		f, err := os.Create(PartPath(p.CPU, year, day, part))
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+			return f.Close()
+		})
+	}
+
+	if p.Trace != "" {
+		f, err := os.Create(PartPath(p.Trace, year, day, part))
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			stop()
+			return nil, err
+		}
+		stops = append(stops, func() error {
+			trace.Stop()
+			return f.Close()
+		})
+	}
+
+	// the memory profile starts last and finishes first, so it only holds
+	// what the part allocated and not the other profiles' work
+	if p.Mem != "" {
+		path := PartPath(p.Mem, year, day, part)
+		before := takeAllocs()
+		stops = append([]func() error{func() error {
+			return writeAllocsFile(path, before)
+		}}, stops...)
+	}
+
+	return stop, nil
+}
+
+// recordEveryAlloc has the runtime record every allocation rather than a
+// sample of them, until the returned func puts the rate back. It should
+// come before a part's input is loaded, so the rate is settled by the time
+// anything is measured.
+func recordEveryAlloc() func() {
+	rate := runtime.MemProfileRate
+	runtime.MemProfileRate = 1
+	return func() { runtime.MemProfileRate = rate }
+}
+
+// allocs is what's been allocated so far at each call stack.
+type allocs map[[32]uintptr]runtime.MemProfileRecord
+
+// takeAllocs snapshots what's been allocated so far. The runtime only
+// publishes allocations at a collection, so it runs one first.
+func takeAllocs() allocs {
+	runtime.GC()
+	records := []runtime.MemProfileRecord{}
+	n, _ := runtime.MemProfile(nil, true)
+	for {
+		// leave room for stacks first seen while the slice is made
+		records = make([]runtime.MemProfileRecord, n+64)
+		var isComplete bool
+		if n, isComplete = runtime.MemProfile(records, true); isComplete {
+			break
+		}
+	}
+
+	a := make(allocs, n)
+	for _, r := range records[:n] {
+		a[r.Stack0] = r
+	}
+	return a
+}
+
+// allocsSince is a profile of what's been allocated since the snapshot
+// before, in the sample types and order runtime/pprof's allocs profile
+// uses. The snapshots' own allocations are left out.
+func allocsSince(before allocs) *profile.Profile {
+	after := takeAllocs()
+	snapshot := runtime.FuncForPC(reflect.ValueOf(takeAllocs).Pointer()).Name()
+
+	p := &profile.Profile{
+		SampleTypes: []string{"alloc_objects", "alloc_space", "inuse_objects", "inuse_space"},
+		SampleUnits: []string{"count", "bytes", "count", "bytes"},
+	}
+	for stack, r := range after {
+		b := before[stack]
+		values := []int64{
+			r.AllocObjects - b.AllocObjects,
+			r.AllocBytes - b.AllocBytes,
+			r.InUseObjects() - b.InUseObjects(),
+			r.InUseBytes() - b.InUseBytes(),
+		}
+		if values[0] == 0 && values[1] == 0 {
+			continue
+		}
+
+		functions := []string{}
+		isSnapshot := false
+		frames := runtime.CallersFrames(r.Stack())
+		for {
+			frame, more := frames.Next()
+			if frame.Function == snapshot {
+				isSnapshot = true
+			}
+			functions = append(functions, frame.Function)
+			if !more {
+				break
+			}
+		}
+		if isSnapshot {
+			continue
+		}
+		// like runtime/pprof, start from whatever called the allocator
+		for len(functions) > 1 && strings.HasPrefix(functions[0], "runtime.") {
+			functions = functions[1:]
+		}
+		p.Samples = append(p.Samples, profile.Sample{Stack: functions, Values: values})
+	}
+	return p
+}
+
+func writeAllocsFile(path string, before allocs) error {
+	p := allocsSince(before)
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := p.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ProfilePart runs a part the given number of times over the named input,
+// writing a CPU profile of those runs to w, or a memory one if mem is set.
+func ProfilePart(ctx context.Context, d Day, part int, inputName string, runs int, mem bool, w io.Writer) error {
+	if part < 1 || part > len(d.Parts) {
+		return fmt.Errorf("%s has no part %d", d, part)
+	}
+	if mem {
+		defer recordEveryAlloc()()
+	}
+	_, _, lines, err := load(d, inputName)
+	if err != nil {
+		return err
+	}
+
+	var before allocs
+	if mem {
+		before = takeAllocs()
+	} else {
+		if err := pprof.StartCPUProfile(w); err != nil {
+			return err
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	f := d.Parts[part-1]
+	for i := 0; i < runs; i++ {
+		if _, err := f(ctx, append([]string(nil), lines...)); err != nil {
+			return err
+		}
+	}
+
+	if mem {
+		return allocsSince(before).Write(w)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/Takadimi/aoc/checkpoint"
+	"github.com/Takadimi/aoc/input"
+)
+
+var ErrTimeout = errors.New("timed out")
+
+// PanicError is a part that panicked instead of returning.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+type Options struct {
+	// Input is a named input (sample, real, sample2, ...) or a file path.
+	Input string
+	// Parts limits the run to these parts; empty runs them all.
+	Parts   []int
+	Workers int
+	// Timeout bounds each part on its own.
+	Timeout time.Duration
+	// SkipExpected leaves answers unchecked, for when day flags change the
+	// question being answered.
+	SkipExpected bool
+	// Profiles profiles each part run. Parts are run one at a time while
+	// profiling so each profile only covers its own part.
+	Profiles Profiles
+	// Checkpoints is where parts that simulate step by step save snapshots,
+	// and which snapshot to resume from.
+	Checkpoints Checkpoints
+}
+
+type Checkpoints struct {
+	// Dir is where snapshots are saved; none are if it's empty.
+	Dir string
+	// Every is how many steps go between snapshots.
+	Every int
+	// Resume is the snapshot the part it was taken of starts from.
+	Resume *checkpoint.Snapshot
+}
+
+// checkpointGrace is how long a cancelled part that's checkpointing gets to
+// save its snapshot before it's abandoned.
+const checkpointGrace = 10 * time.Second
+
+// session is the checkpoint session for a part, if it needs one.
+func (c Checkpoints) session(year, day, part int) *checkpoint.Session {
+	s := &checkpoint.Session{Year: year, Day: day, Part: part, Dir: c.Dir, Every: c.Every}
+	if r := c.Resume; r != nil && r.Year == year && r.Day == day && r.Part == part {
+		s.Resume = r
+	}
+	if s.Dir == "" && s.Resume == nil {
+		return nil
+	}
+	return s
+}
+
+type Result struct {
+	Year, Day, Part int
+	// Variant names the variant of the part this is the result of, and is
+	// empty for the part itself.
+	Variant     string
+	Input       string
+	Path        string
+	Answer      string
+	Expected    string
+	HasExpected bool
+	Err         error
+	Duration    time.Duration
+}
+
+// Failed reports whether the part errored or disagreed with its expected answer.
+func (r Result) Failed() bool {
+	return r.Err != nil || (r.HasExpected && r.Answer != r.Expected)
+}
+
+func (r Result) Status() string {
+	var panicErr *PanicError
+	switch {
+	case errors.As(r.Err, &panicErr):
+		return "panic"
+	case errors.Is(r.Err, ErrTimeout):
+		return "timeout"
+	case r.Err != nil:
+		return "error"
+	case !r.HasExpected:
+		return "-"
+	case r.Answer != r.Expected:
+		return "WRONG"
+	default:
+		return "ok"
+	}
+}
+
+type task struct {
+	day   Day
+	part  int
+	fn    PartFunc
+	path  string
+	lines []string
+}
+
+// Run solves every part of the given days on a pool of workers. Results come
+// back in day then part order no matter which worker finished first, and a
+// part that errors, panics or times out only fails its own result.
+func Run(ctx context.Context, days []Day, opts Options) []Result {
+	if opts.Input == "" {
+		opts.Input = input.Sample
+	}
+	if opts.Workers < 1 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+	if opts.Profiles.Enabled() {
+		opts.Workers = 1
+	}
+	if opts.Profiles.Mem != "" {
+		defer recordEveryAlloc()()
+	}
+
+	tasks := []task{}
+	results := []Result{}
+	for _, d := range days {
+		entry, path, lines, err := load(d, opts.Input)
+
+		for part := 1; part <= len(d.Parts); part++ {
+			if !wantsPart(opts.Parts, part) {
+				continue
+			}
+			r := Result{Year: d.Year, Day: d.Day, Part: part, Input: opts.Input, Path: path, Err: err}
+			if !opts.SkipExpected {
+				r.Expected, r.HasExpected = entry.Expected(part)
+			}
+			results = append(results, r)
+			tasks = append(tasks, task{day: d, part: part, fn: d.Parts[part-1], path: path, lines: lines})
+		}
+	}
+
+	runTasks(ctx, tasks, results, opts)
+	return results
+}
+
+// runTasks runs each task on a pool of workers, filling in the result at
+// the same index. Tasks whose result already has an error are skipped.
+func runTasks(ctx context.Context, tasks []task, results []Result, opts Options) {
+	queue := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				if results[i].Err != nil {
+					continue
+				}
+				t := tasks[i]
+				stopProfiling, err := opts.Profiles.start(t.day.Year, t.day.Day, t.part)
+				if err != nil {
+					results[i].Err = err
+					continue
+				}
+				partCtx, grace := ctx, time.Duration(0)
+				if session := opts.Checkpoints.session(t.day.Year, t.day.Day, t.part); session != nil {
+					partCtx, grace = checkpoint.WithSession(ctx, session), checkpointGrace
+				}
+				start := time.Now()
+				answer, err := runPart(partCtx, t.fn, t.lines, opts.Timeout, grace)
+				results[i].Duration = time.Since(start)
+				if profileErr := stopProfiling(); err == nil {
+					err = profileErr
+				}
+				var parseErr *input.ParseError
+				if errors.As(err, &parseErr) {
+					parseErr.Locate(t.path, t.lines)
+				}
+				results[i].Err = err
+				if err == nil {
+					results[i].Answer = fmt.Sprint(answer)
+				}
+			}
+		}()
+	}
+	for i := range tasks {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+}
+
+func load(d Day, name string) (input.Entry, string, []string, error) {
+	path, err := input.Resolve(d.Year, d.Day, name)
+	if err != nil {
+		return input.Entry{}, "", nil, err
+	}
+
+	// expected answers only exist for named inputs, not arbitrary paths
+	entry := input.Entry{}
+	if dayDir, err := input.DayDir(d.Year, d.Day); err == nil {
+		if e, err := input.Lookup(dayDir, name); err == nil {
+			entry = e
+		}
+	}
+
+	lines, err := input.Lines(path)
+	return entry, path, lines, err
+}
+
+// Runs reports whether running days with only the given parts, or all of
+// them if there are none, would run a day's part.
+func Runs(days []Day, parts []int, year, day, part int) bool {
+	for _, d := range days {
+		if d.Year == year && d.Day == day && part >= 1 && part <= len(d.Parts) && wantsPart(parts, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func wantsPart(parts []int, part int) bool {
+	if len(parts) == 0 {
+		return true
+	}
+	for _, p := range parts {
+		if p == part {
+			return true
+		}
+	}
+	return false
+}
+
+type outcome struct {
+	answer any
+	err    error
+}
+
+// runPart calls a part on its own goroutine so a panic is recovered into an
+// error and a part that ignores its context can still be abandoned once the
+// timeout passes. A cancelled part is waited on for the grace period first,
+// so it can finish saving a checkpoint.
+func runPart(ctx context.Context, part PartFunc, lines []string, timeout, grace time.Duration) (any, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{err: &PanicError{Value: r, Stack: debug.Stack()}}
+			}
+		}()
+		// parts get their own copy of the lines since they run concurrently
+		answer, err := part(ctx, append([]string(nil), lines...))
+		done <- outcome{answer, err}
+	}()
+
+	select {
+	case o := <-done:
+		if errors.Is(o.err, context.DeadlineExceeded) {
+			o.err = fmt.Errorf("%w after %s", ErrTimeout, timeout)
+		}
+		return o.answer, o.err
+	case <-ctx.Done():
+		if grace > 0 {
+			select {
+			case <-done:
+			case <-time.After(grace):
+			}
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w after %s", ErrTimeout, timeout)
+		}
+		return nil, ctx.Err()
+	}
+}
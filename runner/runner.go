@@ -0,0 +1,116 @@
+package runner
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// PartFunc solves one part of a day from the lines of its input.
+type PartFunc func(ctx context.Context, lines []string) (any, error)
+
+type Day struct {
+	Year, Day int
+	// Parts[0] is part one. Day 25 only has the one part.
+	Parts []PartFunc
+	// Flags holds day specific options, parsed from the arguments after
+	// `aoc run <year> <day>`.
+	Flags *flag.FlagSet
+	// Variants are other ways of solving the parts, kept to compare against.
+	Variants []Variant
+	// Generate makes a random input of roughly the given size, to check
+	// the parts and their variants agree on, or to run the parts at scale.
+	Generate func(r *rand.Rand, size int) []string
+}
+
+// Variant is another way of solving one of a day's parts, like the serial
+// version of a part that's registered running in parallel.
+type Variant struct {
+	Name string
+	Part int
+	Func PartFunc
+}
+
+func (d Day) String() string {
+	return fmt.Sprintf("%d/day-%d", d.Year, d.Day)
+}
+
+type key struct {
+	year, day int
+}
+
+var registry = map[key]Day{}
+
+// Register makes a day available to the runner. Days call it from init, so
+// registering the same day twice is a programming error and panics.
+func Register(d Day) {
+	k := key{d.Year, d.Day}
+	if _, isRegistered := registry[k]; isRegistered {
+		panic(fmt.Sprintf("runner: %s registered twice", d))
+	}
+	registry[k] = d
+}
+
+func Lookup(year, day int) (Day, bool) {
+	d, isRegistered := registry[key{year, day}]
+	return d, isRegistered
+}
+
+// Days returns every registered day ordered by year then day.
+func Days() []Day {
+	days := []Day{}
+	for _, d := range registry {
+		days = append(days, d)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Year != days[j].Year {
+			return days[i].Year < days[j].Year
+		}
+		return days[i].Day < days[j].Day
+	})
+	return days
+}
+
+// Solve builds a part out of a parser and a solver for what it parses.
+// The input is parsed fresh for every run so solvers are free to mutate it.
+func Solve[T, A any](parse func(lines []string) (T, error), solve func(T) A) PartFunc {
+	return func(ctx context.Context, lines []string) (any, error) {
+		parsed, err := parse(lines)
+		if err != nil {
+			return nil, err
+		}
+		return solve(parsed), nil
+	}
+}
+
+// SolveErr is Solve for solvers that can fail on input that parsed fine.
+func SolveErr[T, A any](parse func(lines []string) (T, error), solve func(T) (A, error)) PartFunc {
+	return func(ctx context.Context, lines []string) (any, error) {
+		parsed, err := parse(lines)
+		if err != nil {
+			return nil, err
+		}
+		return solve(parsed)
+	}
+}
+
+// SolveCtx is SolveErr for long running solvers that should stop once the
+// part is cancelled or times out.
+func SolveCtx[T, A any](parse func(lines []string) (T, error), solve func(context.Context, T) (A, error)) PartFunc {
+	return func(ctx context.Context, lines []string) (any, error) {
+		parsed, err := parse(lines)
+		if err != nil {
+			return nil, err
+		}
+		return solve(ctx, parsed)
+	}
+}
+
+// SolveLines builds a part out of a solver that works on the raw lines.
+func SolveLines[A any](solve func(lines []string) A) PartFunc {
+	return func(ctx context.Context, lines []string) (any, error) {
+		return solve(lines), nil
+	}
+}
@@ -0,0 +1,179 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Takadimi/aoc/input"
+)
+
+// PrintSummary writes a table of results followed by anything that didn't
+// fit in it: answers spanning several lines and the errors behind failures.
+func PrintSummary(w io.Writer, results []Result) error {
+	details := []string{}
+
+	tw := tabwriter.NewWriter(w, 1, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "YEAR\tDAY\tPART\tINPUT\tANSWER\tEXPECTED\tSTATUS\tTIME")
+	for _, r := range results {
+		heading := fmt.Sprintf("%d day %d part %d (%s):", r.Year, r.Day, r.Part, r.Input)
+
+		answer := r.Answer
+		if strings.Contains(answer, "\n") {
+			details = append(details, heading+"\n"+answer)
+			answer = "(below)"
+		}
+		if r.Err != nil {
+			detail := heading + " " + r.Err.Error()
+			var parseErr *input.ParseError
+			if errors.As(r.Err, &parseErr) {
+				detail += "\n" + parseErr.Snippet()
+			}
+			details = append(details, detail)
+		}
+
+		expected := "-"
+		if r.HasExpected {
+			expected = r.Expected
+		}
+
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s\t%s\t%s\t%s\n", r.Year, r.Day, r.Part, r.Input, answer, expected, r.Status(), r.Duration.Round(time.Microsecond))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, d := range details {
+		if _, err := fmt.Fprintf(w, "\n%s\n", d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PrintMatrix writes the results of RunInputs as a table with a row per
+// input and the answer and time of each part across it, flagging inputs
+// where a part failed or a variant disagreed with it. What's behind the
+// flags follows the table.
+func PrintMatrix(w io.Writer, results []Result) error {
+	inputs := []string{}
+	parts := []int{}
+	cells := map[inputPart]Result{}
+	for _, r := range results {
+		if r.Variant != "" {
+			continue
+		}
+		key := inputPart{r.Path, r.Part}
+		if len(inputs) == 0 || inputs[len(inputs)-1] != r.Path {
+			inputs = append(inputs, r.Path)
+		}
+		if !containsInt(parts, r.Part) {
+			parts = append(parts, r.Part)
+		}
+		cells[key] = r
+	}
+	sort.Ints(parts)
+
+	flags, details := inputFlags(results, cells)
+
+	tw := tabwriter.NewWriter(w, 1, 0, 2, ' ', 0)
+	fmt.Fprint(tw, "INPUT")
+	for _, p := range parts {
+		fmt.Fprintf(tw, "\tPART %d\tTIME", p)
+	}
+	fmt.Fprintln(tw, "\tFLAGS")
+	for _, path := range inputs {
+		fmt.Fprint(tw, filepath.Base(path))
+		for _, p := range parts {
+			r, ran := cells[inputPart{path, p}]
+			if !ran {
+				fmt.Fprint(tw, "\t-\t-")
+				continue
+			}
+			answer := r.Answer
+			switch {
+			case r.Err != nil:
+				answer = r.Status()
+			case strings.Contains(answer, "\n"):
+				details = append(details, inputHeading(r)+"\n"+answer)
+				answer = "(below)"
+			}
+			fmt.Fprintf(tw, "\t%s\t%s", answer, r.Duration.Round(time.Microsecond))
+		}
+		fmt.Fprintf(tw, "\t%s\n", strings.Join(flags[path], ", "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, d := range details {
+		if _, err := fmt.Fprintf(w, "\n%s\n", d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// inputFlags says what's wrong on each input of RunInputs' results, given
+// the results of the parts themselves by input and part, along with the
+// details behind it.
+func inputFlags(results []Result, parts map[inputPart]Result) (map[string][]string, []string) {
+	flags := map[string][]string{}
+	details := []string{}
+	for _, r := range results {
+		if !r.Failed() {
+			continue
+		}
+		// a variant failing is either down to its part failing too or a
+		// disagreement with it, unless it never got to answer
+		if r.Variant != "" && (parts[inputPart{r.Path, r.Part}].Failed() || !unfinished(r)) {
+			continue
+		}
+		name := fmt.Sprintf("part %d", r.Part)
+		if r.Variant != "" {
+			name += " " + r.Variant
+		}
+		flags[r.Path] = append(flags[r.Path], name+" "+r.Status())
+
+		detail := inputHeading(r) + " "
+		if r.Variant != "" {
+			detail += r.Variant + " "
+		}
+		if r.Err == nil {
+			detail += fmt.Sprintf("answered %s but expected %s", r.Answer, r.Expected)
+		} else {
+			detail += r.Err.Error()
+			var parseErr *input.ParseError
+			if errors.As(r.Err, &parseErr) {
+				detail += "\n" + parseErr.Snippet()
+			}
+		}
+		details = append(details, detail)
+	}
+	for _, d := range Disagreements(results) {
+		flags[d.Part.Path] = append(flags[d.Part.Path], fmt.Sprintf("part %d %s disagrees", d.Part.Part, d.Variant.Variant))
+		details = append(details, inputHeading(d.Part)+" "+d.String())
+	}
+
+	return flags, details
+}
+
+func inputHeading(r Result) string {
+	return fmt.Sprintf("%s part %d:", r.Input, r.Part)
+}
+
+func containsInt(s []int, n int) bool {
+	for _, v := range s {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package seq
+
+import "sort"
+
+// Number is any type Sum can add up.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Ordered is any type that supports < and >.
+type Ordered interface {
+	Number | ~string
+}
+
+func Sum[T Number](s []T) T {
+	var sum T
+	for _, v := range s {
+		sum += v
+	}
+	return sum
+}
+
+// Min returns the smallest element, or false if s is empty.
+func Min[T Ordered](s []T) (T, bool) {
+	i := ArgMin(s)
+	if i < 0 {
+		var zero T
+		return zero, false
+	}
+	return s[i], true
+}
+
+// Max returns the largest element, or false if s is empty.
+func Max[T Ordered](s []T) (T, bool) {
+	i := ArgMax(s)
+	if i < 0 {
+		var zero T
+		return zero, false
+	}
+	return s[i], true
+}
+
+// ArgMin is the index of the first smallest element, or -1 if s is empty.
+func ArgMin[T Ordered](s []T) int {
+	best := -1
+	for i, v := range s {
+		if best < 0 || v < s[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// ArgMax is the index of the first largest element, or -1 if s is empty.
+func ArgMax[T Ordered](s []T) int {
+	best := -1
+	for i, v := range s {
+		if best < 0 || v > s[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// TopK returns the k largest elements, largest first, leaving s untouched.
+// It returns all of them when s has fewer than k.
+func TopK[T Ordered](s []T, k int) []T {
+	sorted := append([]T(nil), s...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] > sorted[j]
+	})
+
+	if k < 0 {
+		k = 0
+	}
+	if k < len(sorted) {
+		sorted = sorted[:k]
+	}
+	return sorted
+}
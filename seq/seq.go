@@ -0,0 +1,131 @@
+// Package seq holds the small slice algorithms that keep coming up across
+// days, written once generically.
+package seq
+
+import "fmt"
+
+// IndexError is the error MapErr returns, remembering which element failed.
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("element %d: %v", e.Index, e.Err)
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+func Map[T, U any](s []T, f func(T) U) []U {
+	mapped := make([]U, 0, len(s))
+	for _, v := range s {
+		mapped = append(mapped, f(v))
+	}
+	return mapped
+}
+
+// MapErr is Map for conversions that can fail. It stops at the first failure
+// and returns it as an *IndexError.
+func MapErr[T, U any](s []T, f func(T) (U, error)) ([]U, error) {
+	mapped := make([]U, 0, len(s))
+	for i, v := range s {
+		u, err := f(v)
+		if err != nil {
+			return nil, &IndexError{Index: i, Err: err}
+		}
+		mapped = append(mapped, u)
+	}
+	return mapped, nil
+}
+
+func Filter[T any](s []T, keep func(T) bool) []T {
+	filtered := []T{}
+	for _, v := range s {
+		if keep(v) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+func Reduce[T, A any](s []T, initial A, f func(A, T) A) A {
+	acc := initial
+	for _, v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Count is the number of elements matching pred.
+func Count[T any](s []T, pred func(T) bool) int {
+	count := 0
+	for _, v := range s {
+		if pred(v) {
+			count++
+		}
+	}
+	return count
+}
+
+// Frequencies counts how many times each distinct element appears.
+func Frequencies[T comparable](s []T) map[T]int {
+	frequencies := map[T]int{}
+	for _, v := range s {
+		frequencies[v]++
+	}
+	return frequencies
+}
+
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip pairs up elements by index, stopping at the end of the shorter slice.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	zipped := make([]Pair[A, B], 0, n)
+	for i := 0; i < n; i++ {
+		zipped = append(zipped, Pair[A, B]{a[i], b[i]})
+	}
+	return zipped
+}
+
+// Chunk splits s into consecutive runs of size elements. The last chunk is
+// shorter when len(s) isn't a multiple of size. Chunks share s's backing
+// array.
+func Chunk[T any](s []T, size int) [][]T {
+	if size < 1 {
+		panic(fmt.Sprintf("seq: chunk size %d is less than 1", size))
+	}
+
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	for start := 0; start < len(s); start += size {
+		end := start + size
+		if end > len(s) {
+			end = len(s)
+		}
+		chunks = append(chunks, s[start:end:end])
+	}
+	return chunks
+}
+
+// Windows returns every run of size consecutive elements, each overlapping
+// the last by all but one. Windows share s's backing array.
+func Windows[T any](s []T, size int) [][]T {
+	if size < 1 {
+		panic(fmt.Sprintf("seq: window size %d is less than 1", size))
+	}
+
+	windows := [][]T{}
+	for start := 0; start+size <= len(s); start++ {
+		windows = append(windows, s[start:start+size:start+size])
+	}
+	return windows
+}
@@ -0,0 +1,137 @@
+package set
+
+import "sort"
+
+// Counter is a multiset: a set that remembers how many times each value was
+// added. Its size, total and number of repeated values are kept up to date
+// as it changes, so checking them stays O(1) however the counter is used,
+// e.g. as a sliding window.
+type Counter[T comparable] struct {
+	counts   map[T]int
+	total    int
+	repeated int
+}
+
+type Entry[T comparable] struct {
+	Value T
+	Count int
+}
+
+func NewCounter[T comparable](values ...T) *Counter[T] {
+	c := &Counter[T]{
+		counts: make(map[T]int),
+	}
+	for _, v := range values {
+		c.Add(v, 1)
+	}
+	return c
+}
+
+// Add counts value n more times. n below 1 is ignored.
+func (c *Counter[T]) Add(value T, n int) {
+	if n < 1 {
+		return
+	}
+	c.set(value, c.counts[value]+n)
+}
+
+// Remove counts value n fewer times, forgetting it once its count reaches
+// zero. n below 1 is ignored.
+func (c *Counter[T]) Remove(value T, n int) {
+	if n < 1 {
+		return
+	}
+	count := c.counts[value] - n
+	if count < 0 {
+		count = 0
+	}
+	c.set(value, count)
+}
+
+func (c *Counter[T]) set(value T, count int) {
+	before := c.counts[value]
+	if before > 1 {
+		c.repeated--
+	}
+	if count > 1 {
+		c.repeated++
+	}
+	c.total += count - before
+
+	if count == 0 {
+		delete(c.counts, value)
+	} else {
+		c.counts[value] = count
+	}
+}
+
+// Count is how many times value has been added, 0 if never.
+func (c *Counter[T]) Count(value T) int {
+	return c.counts[value]
+}
+
+// Len is the number of distinct values counted.
+func (c *Counter[T]) Len() int {
+	return len(c.counts)
+}
+
+// Total is the sum of every value's count.
+func (c *Counter[T]) Total() int {
+	return c.total
+}
+
+// Repeated is the number of distinct values counted more than once.
+func (c *Counter[T]) Repeated() int {
+	return c.repeated
+}
+
+func (c *Counter[T]) Values() []T {
+	values := []T{}
+	for value := range c.counts {
+		values = append(values, value)
+	}
+	return values
+}
+
+// MostCommon returns the n values with the highest counts, highest first.
+// Values with equal counts come in no particular order. A negative n returns
+// every value.
+func (c *Counter[T]) MostCommon(n int) []Entry[T] {
+	entries := []Entry[T]{}
+	for value, count := range c.counts {
+		entries = append(entries, Entry[T]{value, count})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Count > entries[j].Count
+	})
+
+	if n >= 0 && n < len(entries) {
+		entries = entries[:n]
+	}
+	return entries
+}
+
+// AtLeast returns the values counted k or more times.
+func (c *Counter[T]) AtLeast(k int) []T {
+	values := []T{}
+	for value, count := range c.counts {
+		if count >= k {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Merge adds other's counts to c.
+func (c *Counter[T]) Merge(other *Counter[T]) {
+	for value, count := range other.counts {
+		c.Add(value, count)
+	}
+}
+
+// Subtract removes other's counts from c, stopping each value at zero.
+func (c *Counter[T]) Subtract(other *Counter[T]) {
+	for value, count := range other.counts {
+		c.Remove(value, count)
+	}
+}
@@ -0,0 +1,136 @@
+// Package trace is debug output for days, built on log/slog. Days log named
+// events through a Tracer, and nothing is written unless the event's
+// category has been switched on, so answers aren't buried in debug output.
+package trace
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Pattern switches on the events of a category, and of every category
+// below it: `monkeys` covers `monkeys.round`. Categories can be matched
+// with wildcards as in path.Match, so `*` switches on everything. A day
+// written before the category, as in `2022/day-11:monkeys.round`, only
+// switches it on for that day.
+type Pattern struct {
+	Day      string
+	Category string
+}
+
+// ParsePatterns reads comma separated patterns.
+func ParsePatterns(s string) ([]Pattern, error) {
+	patterns := []Pattern{}
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		p := Pattern{Category: field}
+		if day, category, hasDay := strings.Cut(field, ":"); hasDay {
+			p = Pattern{Day: day, Category: category}
+		}
+		if p.Category == "" {
+			return nil, fmt.Errorf("trace pattern %q has no category", field)
+		}
+		if _, err := path.Match(p.Category, ""); err != nil {
+			return nil, fmt.Errorf("trace pattern %q: %w", field, err)
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+func (p Pattern) matches(day, category string) bool {
+	if p.Day != "" && p.Day != day {
+		return false
+	}
+	for {
+		if isMatch, _ := path.Match(p.Category, category); isMatch {
+			return true
+		}
+		// try the category's parents in turn
+		dot := strings.LastIndex(category, ".")
+		if dot < 0 {
+			return false
+		}
+		category = category[:dot]
+	}
+}
+
+var (
+	mu       sync.RWMutex
+	patterns []Pattern
+	logger   *slog.Logger
+)
+
+// Enable sends events matching any of the patterns to h, replacing whatever
+// was enabled before.
+func Enable(h slog.Handler, ps ...Pattern) {
+	mu.Lock()
+	defer mu.Unlock()
+	patterns = ps
+	logger = slog.New(h)
+}
+
+// Disable stops every event being written.
+func Disable() {
+	mu.Lock()
+	defer mu.Unlock()
+	patterns = nil
+	logger = nil
+}
+
+// Tracer logs a day's events, each tagged with the day and its category.
+type Tracer struct {
+	day string
+}
+
+// New is a tracer for the day, named like `2022/day-11`.
+func New(day string) *Tracer {
+	return &Tracer{day: day}
+}
+
+// Enabled reports whether events of a category at a level would be written,
+// so anything costly to describe only needs describing when they would be.
+func (t *Tracer) Enabled(level slog.Level, category string) bool {
+	_, isEnabled := t.logger(level, category)
+	return isEnabled
+}
+
+func (t *Tracer) logger(level slog.Level, category string) (*slog.Logger, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if logger == nil || !logger.Enabled(context.Background(), level) {
+		return nil, false
+	}
+	for _, p := range patterns {
+		if p.matches(t.day, category) {
+			return logger, true
+		}
+	}
+	return nil, false
+}
+
+// Log writes an event of a category, with args as key value pairs like
+// slog.Logger.Log takes.
+func (t *Tracer) Log(level slog.Level, category, msg string, args ...any) {
+	l, isEnabled := t.logger(level, category)
+	if !isEnabled {
+		return
+	}
+	l.Log(context.Background(), level, msg, append([]any{"day", t.day, "category", category}, args...)...)
+}
+
+func (t *Tracer) Debug(category, msg string, args ...any) {
+	t.Log(slog.LevelDebug, category, msg, args...)
+}
+
+func (t *Tracer) Info(category, msg string, args ...any) {
+	t.Log(slog.LevelInfo, category, msg, args...)
+}
@@ -0,0 +1,65 @@
+package vm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Takadimi/aoc/input"
+)
+
+// Assemble parses one instruction per line: a mnemonic followed by its
+// operands, separated by whitespace or commas. Operands are integers or
+// register names.
+func Assemble(isa ISA, lines []string) ([]Instruction, error) {
+	program := []Instruction{}
+	for i, l := range lines {
+		l = strings.ReplaceAll(l, ",", " ")
+		fields := strings.Fields(l)
+		if len(fields) == 0 {
+			return nil, input.Expected(i, 0, "an instruction")
+		}
+
+		op, isKnown := isa[fields[0]]
+		if !isKnown {
+			return nil, input.Expected(i, input.FieldColumn(l, 0), "an instruction of "+strings.Join(isa.Mnemonics(), ", "))
+		}
+		if len(fields)-1 != op.Arity {
+			column := input.FieldColumn(l, min(len(fields), op.Arity+1))
+			return nil, input.Expected(i, column, fmt.Sprintf("%d operands after %s", op.Arity, op.Mnemonic))
+		}
+
+		args := []Operand{}
+		for n, field := range fields[1:] {
+			arg, err := parseOperand(field)
+			if err != nil {
+				return nil, input.ExpectedErr(i, input.FieldColumn(l, n+1), "an integer or register name", err)
+			}
+			args = append(args, arg)
+		}
+
+		program = append(program, Instruction{Mnemonic: op.Mnemonic, Args: args})
+	}
+
+	return program, nil
+}
+
+func parseOperand(field string) (Operand, error) {
+	first := []rune(field)[0]
+	if unicode.IsLetter(first) || first == '_' {
+		return Operand{Register: field}, nil
+	}
+	value, err := strconv.Atoi(field)
+	if err != nil {
+		return Operand{}, err
+	}
+	return Operand{Value: value}, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
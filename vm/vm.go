@@ -0,0 +1,137 @@
+// Package vm runs small register machine programs, like the ones puzzles
+// keep describing, from a table of opcodes each puzzle supplies.
+package vm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Registers holds the value of every named register. Registers that were
+// never written read as 0.
+type Registers map[string]int
+
+// Operand is an instruction argument: a register or a literal value.
+type Operand struct {
+	// Register is empty for a literal.
+	Register string
+	Value    int
+}
+
+type Instruction struct {
+	Mnemonic string
+	Args     []Operand
+}
+
+// Opcode describes one instruction of an instruction set.
+type Opcode struct {
+	Mnemonic string
+	Arity    int
+	// Cycles is how many cycles the instruction takes. Its effect only
+	// lands once they've all passed.
+	Cycles int
+	// Exec carries out the instruction, returning how far to move the
+	// program counter: 1 for the next instruction, anything else to jump.
+	Exec func(m *Machine, args []Operand) int
+}
+
+// ISA is an instruction set, opcodes by mnemonic.
+type ISA map[string]Opcode
+
+func NewISA(opcodes ...Opcode) ISA {
+	isa := ISA{}
+	for _, op := range opcodes {
+		isa[op.Mnemonic] = op
+	}
+	return isa
+}
+
+// Mnemonics lists the instruction set's mnemonics alphabetically.
+func (isa ISA) Mnemonics() []string {
+	mnemonics := []string{}
+	for mnemonic := range isa {
+		mnemonics = append(mnemonics, mnemonic)
+	}
+	sort.Strings(mnemonics)
+	return mnemonics
+}
+
+type Machine struct {
+	ISA       ISA
+	Program   []Instruction
+	Registers Registers
+	// PC is the index of the next instruction to run.
+	PC int
+	// Cycle is the cycle under way while hooks run, counting from 1, and
+	// the number of cycles completed otherwise.
+	Cycle int
+
+	hooks []func(m *Machine)
+}
+
+func New(isa ISA, program []Instruction, registers Registers) *Machine {
+	if registers == nil {
+		registers = Registers{}
+	}
+	return &Machine{ISA: isa, Program: program, Registers: registers}
+}
+
+// OnCycle adds a hook called during every cycle, after any earlier
+// instruction has taken effect but before the one running finishes.
+func (m *Machine) OnCycle(hook func(m *Machine)) {
+	m.hooks = append(m.hooks, hook)
+}
+
+// Value reads an operand.
+func (m *Machine) Value(o Operand) int {
+	if o.Register != "" {
+		return m.Registers[o.Register]
+	}
+	return o.Value
+}
+
+// Halted reports whether the program counter has left the program.
+func (m *Machine) Halted() bool {
+	return m.PC < 0 || m.PC >= len(m.Program)
+}
+
+// Step runs the next instruction through all of its cycles.
+func (m *Machine) Step() error {
+	if m.Halted() {
+		return fmt.Errorf("vm: step after halting at %d", m.PC)
+	}
+
+	instruction := m.Program[m.PC]
+	op, isKnown := m.ISA[instruction.Mnemonic]
+	if !isKnown {
+		return fmt.Errorf("vm: instruction %d: unknown mnemonic %q", m.PC, instruction.Mnemonic)
+	}
+	if len(instruction.Args) != op.Arity {
+		return fmt.Errorf("vm: instruction %d: %s takes %d operands, got %d", m.PC, op.Mnemonic, op.Arity, len(instruction.Args))
+	}
+
+	for c := 0; c < op.Cycles; c++ {
+		m.Cycle++
+		for _, hook := range m.hooks {
+			hook(m)
+		}
+	}
+	m.PC += op.Exec(m, instruction.Args)
+
+	return nil
+}
+
+// Run steps through the program until it halts. Programs that jump can loop
+// forever, so it also stops once ctx is done.
+func (m *Machine) Run(ctx context.Context) error {
+	for !m.Halted() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := m.Step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}